@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
-	"text/tabwriter"
 )
 
 // Diff prints a diff between two values to stdout.
@@ -47,6 +46,8 @@ func Diff(a, b any) {
 //	// + }
 func (d *Dumper) Diff(a, b any) {
 	fmt.Fprint(d.writer, d.DiffStr(a, b))
+	d.writeExtra(func(plain *Dumper) string { return plain.DiffStr(a, b) })
+	d.flush()
 }
 
 // DiffStr returns a string diff between two values.
@@ -105,6 +106,57 @@ func (d *Dumper) DiffStr(a, b any) string {
 	return sb.String()
 }
 
+// DumpCompareJSON returns a line diff of the pretty-printed JSON of two values.
+// @group Diff
+//
+// Example: compare API payloads
+//
+//	a := map[string]int{"a": 1}
+//	b := map[string]int{"a": 2}
+//	out := godump.DumpCompareJSON(a, b)
+//	_ = out
+//	// <#diff // path:line
+//	//   {
+//	// -   "a": 1
+//	// +   "a": 2
+//	//   }
+func DumpCompareJSON(a, b any) string {
+	return defaultDumper.DumpCompareJSON(a, b)
+}
+
+// DumpCompareJSON returns a line diff of the pretty-printed JSON of two values.
+// Each side is rendered with DumpJSONStr, so a value that cannot be marshaled
+// shows up as its {"error": ...} object.
+// @group Diff
+//
+// Example: compare API payloads with a custom dumper
+//
+//	d := godump.NewDumper()
+//	a := map[string]any{"id": 1, "name": "old"}
+//	b := map[string]any{"id": 1, "name": "new"}
+//	out := d.DumpCompareJSON(a, b)
+//	_ = out
+//	// <#diff // path:line
+//	//   {
+//	//     "id": 1,
+//	// -   "name": "old"
+//	// +   "name": "new"
+//	//   }
+func (d *Dumper) DumpCompareJSON(a, b any) string {
+	var sb strings.Builder
+	d.printDiffHeader(&sb)
+	d.ensureColorizer()
+
+	ops := diffLines(splitLines(d.DumpJSONStr(a)), splitLines(d.DumpJSONStr(b)))
+	for _, op := range ops {
+		sb.WriteString(d.diffPrefix(op.kind))
+		sb.WriteString(d.diffTintLine(op.text, op.kind))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
 // DiffHTML returns an HTML diff between two values.
 // @group Diff
 //
@@ -169,11 +221,11 @@ func (d *Dumper) dumpStrNoHeader(vs ...any) string {
 	d.ensureColorizer()
 	state := newDumpState()
 
-	var sb strings.Builder
-	tw := tabwriter.NewWriter(&sb, 0, 0, 1, ' ', 0)
-	d.writeDump(tw, state, vs...)
-	tw.Flush()
-	return sb.String()
+	buf := getDumpBuffer()
+	defer putDumpBuffer(buf)
+	d.writeDump(buf.tw, state, vs...)
+	buf.tw.Flush()
+	return buf.out.String()
 }
 
 // printDiffHeader writes the diff header line when a caller frame is available.
@@ -194,7 +246,7 @@ func (d *Dumper) printDiffHeader(out io.Writer) {
 	}
 
 	header := fmt.Sprintf("<#diff // %s:%d", relPath, line)
-	fmt.Fprintln(out, d.colorize(colorGray, header))
+	fmt.Fprintln(out, d.colorize(d.theme.MetaColor, header))
 }
 
 // typeStringForAny returns a displayable type for a value.
@@ -277,9 +329,9 @@ func diffLines(a, b []string) []diffLine {
 func (d *Dumper) diffPrefix(kind diffKind) string {
 	switch kind {
 	case diffDelete:
-		return d.colorize(colorRed, "-") + " "
+		return d.colorize(d.theme.DiffRemoveColor, "-") + " "
 	case diffInsert:
-		return d.colorize(colorGreen, "+") + " "
+		return d.colorize(d.theme.DiffAddColor, "+") + " "
 	default:
 		return "  "
 	}
@@ -293,18 +345,22 @@ func (d *Dumper) diffTintLine(line string, kind diffKind) string {
 
 	switch kind {
 	case diffDelete:
-		return d.tintBackgroundLine(line, colorRedBg, "#221010")
+		return d.tintBackgroundLine(line, d.theme.DiffRemoveBackground)
 	case diffInsert:
-		return d.tintBackgroundLine(line, colorGreenBg, "#102216")
+		return d.tintBackgroundLine(line, d.theme.DiffAddBackground)
 	default:
 		return line
 	}
 }
 
 // tintBackgroundLine applies a full-line background while preserving text colors.
-func (d *Dumper) tintBackgroundLine(line, bgCode, bgHex string) string {
+// An empty bgCode leaves the line untouched.
+func (d *Dumper) tintBackgroundLine(line, bgCode string) string {
+	if bgCode == "" {
+		return line
+	}
 	if isHTMLLine(line) {
-		return `<span style="background-color:` + bgHex + `; display:block; width:100%;">` + line + `</span>`
+		return `<span style="background-color:` + htmlBackground(bgCode) + `; display:block; width:100%;">` + line + `</span>`
 	}
 
 	if strings.Contains(line, string(ansiEscape)+"[") {
@@ -314,6 +370,19 @@ func (d *Dumper) tintBackgroundLine(line, bgCode, bgHex string) string {
 	return bgCode + line + ansiEraseLine + colorReset
 }
 
+// htmlBackground converts an ANSI background code to an HTML color, using the known
+// palette or decoding a 24-bit "\033[48;2;r;g;bm" code.
+func htmlBackground(code string) string {
+	if color, ok := htmlColorMap[code]; ok {
+		return color
+	}
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(code, "\033[48;2;%d;%d;%dm", &r, &g, &b); err == nil {
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	return "inherit"
+}
+
 const ansiEscape = '\x1b'
 const ansiEraseLine = "\x1b[K"
 
@@ -146,8 +146,12 @@ func TestDiffTintHelpers(t *testing.T) {
 	d := NewDumper()
 	d.colorizer = colorizeUnstyled
 
-	line := d.tintBackgroundLine(string(ansiEscape)+"[33mabc"+string(ansiEscape)+"[0m", colorRedBg, "#3a0d0d")
+	line := d.tintBackgroundLine(string(ansiEscape)+"[33mabc"+string(ansiEscape)+"[0m", colorRedBg)
 	assert.Contains(t, line, "abc")
+	assert.Equal(t, "abc", d.tintBackgroundLine("abc", ""))
+	assert.Equal(t, "#221010", htmlBackground(colorRedBg))
+	assert.Equal(t, "#0a141e", htmlBackground("\033[48;2;10;20;30m"))
+	assert.Equal(t, "inherit", htmlBackground("bogus"))
 
 	assert.Equal(t, "abc", stripANSI(string(ansiEscape)+"[31mabc"+string(ansiEscape)+"[0m"))
 	assert.Equal(t, "abc", stripANSI("abc"))
@@ -163,6 +167,45 @@ func TestDiffTintHelpers(t *testing.T) {
 	htmlBroken := `<span style="color:#999"broken`
 	assert.Equal(t, htmlBroken, stripHTMLSpans(htmlBroken))
 
-	line = d.tintBackgroundLine(html, colorRedBg, "#3a0d0d")
+	line = d.tintBackgroundLine(html, colorRedBg)
 	assert.Contains(t, line, "x")
+	assert.Contains(t, line, "background-color:#221010")
+}
+
+func TestDiffThemeColors(t *testing.T) {
+	theme := DefaultTheme()
+	theme.DiffAddColor = RGB(0, 200, 0)
+	theme.DiffRemoveColor = RGB(200, 0, 0)
+	theme.DiffAddBackground = "\033[48;2;0;40;0m"
+	theme.DiffRemoveBackground = ""
+	d := NewDumper(WithoutHeader(), WithTheme(theme))
+	d.colorizer = colorizeANSI
+
+	out := d.DiffStr(1, 2)
+	assert.Contains(t, out, RGB(200, 0, 0)+"-"+colorReset)
+	assert.Contains(t, out, RGB(0, 200, 0)+"+"+colorReset+" \033[48;2;0;40;0m")
+	assert.NotContains(t, out, colorRedBg)
+	assert.NotContains(t, out, colorGreenBg)
+}
+
+func TestDumpCompareJSON(t *testing.T) {
+	d := NewDumper(WithoutHeader(), WithoutColor())
+
+	out := d.DumpCompareJSON(
+		map[string]any{"id": 1, "name": "old", "legacy": true},
+		map[string]any{"id": 1, "name": "new", "tags": []string{"a"}},
+	)
+	assert.Contains(t, out, "    \"id\": 1,\n")
+	assert.Contains(t, out, "-   \"legacy\": true,\n")
+	assert.Contains(t, out, "-   \"name\": \"old\"\n")
+	assert.Contains(t, out, "+   \"name\": \"new\",\n")
+	assert.Contains(t, out, "+   \"tags\": [\n")
+	assert.Contains(t, out, "+     \"a\"\n")
+
+	same := d.DumpCompareJSON(map[string]int{"a": 1}, map[string]int{"a": 1})
+	assert.Equal(t, "  {\n    \"a\": 1\n  }\n", same)
+
+	bad := d.DumpCompareJSON(map[string]int{"a": 1}, func() {})
+	assert.Contains(t, bad, "- {\n")
+	assert.Contains(t, bad, `+ {"error":"json: unsupported type: func()"}`)
 }
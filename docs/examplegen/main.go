@@ -388,11 +388,22 @@ func writeMain(base string, fd *FuncDoc, importPath string) error {
 		{token: "os.", path: "os"},
 		{token: "context.", path: "context"},
 		{token: "regexp.", path: "regexp"},
+		{token: "reflect.", path: "reflect"},
+		{token: "reflect.", path: "reflect"},
 		{token: "redis.", path: "github.com/redis/go-redis/v9"},
 		{token: "time.", path: "time"},
 		{token: "gocron", path: "github.com/go-co-op/gocron/v2"},
 		{token: "scheduler", path: "github.com/goforj/scheduler"},
 		{token: "filepath.", path: "path/filepath"},
+		{token: "fstest.", path: "testing/fstest"},
+		{token: "list.", path: "container/list"},
+		{token: "sync.", path: "sync"},
+		{token: "atomic.", path: "sync/atomic"},
+		{token: "url.", path: "net/url"},
+		{token: " io.", path: "io"},
+		{token: "json.", path: "encoding/json"},
+		{token: "bufio.", path: "bufio"},
+		{token: "slog.", path: "log/slog"},
 		{token: "godump.", path: "github.com/goforj/godump"},
 		{token: "rand.", path: "crypto/rand"},
 		{token: "base64.", path: "encoding/base64"},
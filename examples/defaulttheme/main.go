@@ -0,0 +1,16 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DefaultTheme returns the built-in color palette.
+
+	// Example: start from the default palette
+	theme := godump.DefaultTheme()
+	theme.StringColor = "\033[34m"
+	d := godump.NewDumper(godump.WithTheme(theme))
+	_ = d
+}
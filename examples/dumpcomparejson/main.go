@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpCompareJSON returns a line diff of the pretty-printed JSON of two values.
+	// Each side is rendered with DumpJSONStr, so a value that cannot be marshaled
+	// shows up as its {"error": ...} object.
+
+	// Example: compare API payloads with a custom dumper
+	d := godump.NewDumper()
+	a := map[string]any{"id": 1, "name": "old"}
+	b := map[string]any{"id": 1, "name": "new"}
+	out := d.DumpCompareJSON(a, b)
+	_ = out
+	// <#diff // path:line
+	//   {
+	//     "id": 1,
+	// -   "name": "old"
+	// +   "name": "new"
+	//   }
+}
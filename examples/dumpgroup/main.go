@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpGroup prints a labeled banner to the configured writer and calls fn with a
+	// dumper that shares d's configuration but writes one level further indented.
+	// Groups can be nested by calling DumpGroup on the dumper passed to fn.
+
+	// Example: group related dumps
+	d := godump.NewDumper()
+	d.DumpGroup("user", func(d *godump.Dumper) {
+		d.Dump(map[string]int{"id": 1})
+	})
+	// #group "user" {
+	//   #map[string]int {
+	//     id => 1 #int
+	//   }
+	// }
+}
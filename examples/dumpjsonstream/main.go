@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"os"
+)
+
+func main() {
+	// DumpJSONStream writes values as pretty-printed JSON to w, encoding slice elements one at a time.
+
+	// Example: stream JSON
+	v := []int{1, 2}
+	_ = godump.DumpJSONStream(os.Stdout, v)
+	// [
+	//   1,
+	//   2
+	// ]
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"strings"
+)
+
+func main() {
+	// DumpN writes the formatted dump of values to w in a single write and returns the
+	// number of bytes written and any write error.
+
+	// Example: dump to writer with a custom dumper
+	var b strings.Builder
+	d := godump.NewDumper()
+	n, err := d.DumpN(&b, 1)
+	_, _ = n, err
+	// 7 <nil>
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpPath returns a dump of the value found at a slash-separated path inside v.
+	// Segments select struct fields by name, slice and array elements by index, and map
+	// entries by key, using JSON Pointer escaping (~1 for "/", ~0 for "~").
+	// An invalid path renders an error line instead of a dump.
+
+	// Example: dump a slice element with a custom dumper
+	type User struct {
+		Tags []string
+	}
+	d := godump.NewDumper()
+	out := d.DumpPath(User{Tags: []string{"admin", "ops"}}, "/Tags/1")
+	_ = out
+	// "ops" #string
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"time"
+)
+
+func main() {
+	// DumpRaw prints the values by their underlying kinds, bypassing Stringer and type formatters.
+
+	// Example: dump raw structure with a custom dumper
+	d := godump.NewDumper()
+	v := time.Duration(3)
+	d.DumpRaw(v)
+	// 3 #time.Duration
+}
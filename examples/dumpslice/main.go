@@ -0,0 +1,18 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpSlice prints each element of a slice or array as its own top-level dump.
+	// Non-slice input prints an error line instead of a dump.
+
+	// Example: dump elements separately with a custom dumper
+	d := godump.NewDumper()
+	v := []string{"a", "b"}
+	d.DumpSlice(v)
+	// [0] "a" #string
+	// [1] "b" #string
+}
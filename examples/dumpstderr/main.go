@@ -0,0 +1,17 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpStderr prints the values to stderr, keeping debug output out of a program's stdout.
+
+	// Example: dump to stderr
+	v := map[string]int{"a": 1}
+	godump.DumpStderr(v)
+	// #map[string]int {
+	//   a => 1 #int
+	// }
+}
@@ -0,0 +1,18 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpYAML prints the values as YAML to the configured writer.
+
+	// Example: print YAML
+	v := map[string][]int{"a": {1, 2}}
+	d := godump.NewDumper()
+	d.DumpYAML(v)
+	// a:
+	//   - 1
+	//   - 2
+}
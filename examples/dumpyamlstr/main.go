@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// DumpYAMLStr renders values as YAML and returns it as a string. Values are encoded
+	// like DumpJSONStr, so json tags and MarshalJSON methods apply, and fields keep their order.
+
+	// Example: dump YAML string
+	type User struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	d := godump.NewDumper()
+	out := d.DumpYAMLStr(User{Name: "Ada", Tags: []string{"admin"}})
+	_ = out
+	// name: Ada
+	// tags:
+	//   - admin
+}
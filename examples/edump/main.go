@@ -0,0 +1,17 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// Edump is a short alias for DumpStderr.
+
+	// Example: dump to stderr
+	v := map[string]int{"a": 1}
+	godump.Edump(v)
+	// #map[string]int {
+	//   a => 1 #int
+	// }
+}
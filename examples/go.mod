@@ -5,3 +5,5 @@ go 1.18
 replace github.com/goforj/godump => ../
 
 require github.com/goforj/godump v0.0.0-00010101000000-000000000000
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -0,0 +1,16 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// MonochromeTheme returns a theme with no colors, for terminals or logs where only
+	// the layout matters.
+
+	// Example: dump without colors
+	d := godump.NewDumper(godump.WithTheme(godump.MonochromeTheme()))
+	d.Dump("hello")
+	// "hello" #string
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"log/slog"
+)
+
+func main() {
+	// NewSlogHandler returns a slog.Handler that renders each attribute with a Dumper
+	// built from opts and writes records to its writer. Scalars stay on one line and
+	// structs, maps, and slices are dumped in full below their key. Records start with
+	// the time, level, and message, followed by the dump header for the logging call
+	// unless WithoutHeader is set. Groups prefix their attribute keys, e.g. req.id.
+	// Records below slog.LevelInfo are dropped unless WithSlogLevel lowers the minimum.
+
+	// Example: pretty attributes in structured logs
+	type User struct {
+		Name string
+	}
+	logger := slog.New(godump.NewSlogHandler(godump.WithoutHeader()))
+	logger.Info("signed in", "user", User{Name: "Ada"})
+	// 2026-01-02T15:04:05Z INFO signed in
+	//   user => #godump.User {
+	//     +Name => "Ada" #string
+	//   }
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// RegisterFormatter renders values of type t with fn, shown with their type marker,
+	// in place of the default rendering, including Stringer output and the built-in
+	// formatters for types like time.Time. It shares the registry used by WithLazyFormatter.
+	// The registry is copied on write, so other dumpers are never affected. Register
+	// formatters before dumping; RegisterFormatter is not safe to call concurrently with a dump.
+
+	// Example: render a domain type
+	type UserID uint32
+	d := godump.NewDumper()
+	d.RegisterFormatter(reflect.TypeOf(UserID(0)), func(v reflect.Value) string {
+		return fmt.Sprintf("user-%08x", v.Uint())
+	})
+	d.Dump(UserID(0xdeadbeef))
+	// user-deadbeef #godump.UserID
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"os"
+)
+
+func main() {
+	// Reset clears state the dumper caches between dumps while keeping its configuration.
+	// It drops the color mode detected from NO_COLOR, FORCE_COLOR, TERM, and COLORTERM on
+	// first use, so the next dump detects it again. Options such as writers, limits, themes,
+	// redaction rules, and registered formatters are retained. Reference IDs, cycle tracking,
+	// and field paths are scoped to each dump call and always restart at &1.
+	// Reset must not be called concurrently with a dump on the same dumper.
+
+	// Example: pick up a changed environment
+	d := godump.NewDumper()
+	d.Dump("before")
+	os.Setenv("NO_COLOR", "1")
+	d.Reset()
+	d.Dump("after")
+	// "after" #string
+}
@@ -0,0 +1,16 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// RGB returns the 24-bit ANSI color code for r, g, b, for use in a [Theme].
+
+	// Example: brand colors
+	theme := godump.DefaultTheme()
+	theme.StringColor = godump.RGB(0, 173, 216)
+	d := godump.NewDumper(godump.WithTheme(theme), godump.WithTrueColor())
+	_ = d
+}
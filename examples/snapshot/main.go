@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// Snapshot returns a deterministic dump of v for golden-file and snapshot tests.
+	// Output has no colors and no header, and map keys are sorted so repeated runs match byte for byte.
+	// Struct fields keep their declaration order, which is already stable.
+
+	// Example: snapshot a map
+	v := map[string]int{"b": 2, "a": 1}
+	out := godump.Snapshot(v)
+	fmt.Println(out)
+	// #map[string]int {
+	//   a => 1 #int
+	//   b => 2 #int
+	// }
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithArrayIndexWidth right-justifies slice and array indices to a fixed width.
+	// Param n must be greater than 0 or this will be ignored, and the width is derived from
+	// the highest printed index so that `  0 =>` lines up with `100 =>`.
+
+	// Example: pad indices
+	// Default: automatic
+	v := []int{1, 2, 3}
+	d := godump.NewDumper(godump.WithArrayIndexWidth(3))
+	d.Dump(v)
+	// #[]int [
+	//     0 => 1 #int
+	//     1 => 2 #int
+	//     2 => 3 #int
+	// ]
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"bufio"
+	"github.com/goforj/godump"
+	"os"
+)
+
+func main() {
+	// WithAutoFlush flushes the writer after every Dump, DumpSlice, DumpJSON, and Diff when it
+	// has a Flush() error method, like *bufio.Writer, so output isn't lost if the program exits.
+	// Extra writers are flushed too.
+
+	// Example: dump through a buffered writer
+	// Default: false
+	w := bufio.NewWriter(os.Stdout)
+	d := godump.NewDumper(godump.WithWriter(w), godump.WithAutoFlush())
+	d.Dump(1)
+	// 1 #int
+}
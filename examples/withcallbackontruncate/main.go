@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithCallbackOnTruncate registers fn to be called whenever output is truncated.
+	// The kind is "string", "slice", "map", or "depth"; path is the location of the
+	// truncated value (rooted at "$"); total is the full length, or the nesting depth for "depth".
+
+	// Example: count lossy dumps
+	// Default: nil
+	truncations := 0
+	d := godump.NewDumper(
+		godump.WithMaxItems(2),
+		godump.WithCallbackOnTruncate(func(kind, path string, total int) {
+			truncations++
+		}),
+	)
+	d.Dump([]int{1, 2, 3})
+	// truncations == 1
+}
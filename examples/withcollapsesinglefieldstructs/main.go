@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithCollapseSingleFieldStructs renders structs with a single exported scalar field inline.
+	// Newtype-style wrappers print as #Type(value) instead of a full block.
+
+	// Example: collapse wrapper structs
+	// Default: false
+	type Celsius struct {
+		V float64
+	}
+	d := godump.NewDumper(godump.WithCollapseSingleFieldStructs())
+	d.Dump(Celsius{V: 21.5})
+	// #godump.Celsius(21.500000)
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithComparableKeyDedup shows map values that point to an already dumped object as
+	// ↩︎ references, revealing structure shared between keys, e.g. in caches. Keys are
+	// visited in sorted order so the first key owning a pointer gets the full dump.
+
+	// Example: reveal shared cache entries
+	// Default: false
+	type Entry struct {
+		ID int
+	}
+	shared := &Entry{ID: 1}
+	d := godump.NewDumper(godump.WithComparableKeyDedup())
+	d.Dump(map[string]*Entry{"a": shared, "b": shared})
+	// #map[string]*godump.Entry {
+	//    a => #*godump.Entry {
+	//     +ID => 1 #int
+	//   }
+	//    b => ↩︎ &1
+	// }
+}
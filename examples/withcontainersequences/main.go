@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"container/list"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// WithContainerSequences renders container/list and container/ring values as their element sequences.
+	// Lists print front to back and rings once around, instead of their internal node pointers.
+
+	// Example: dump a linked list
+	// Default: false
+	l := list.New()
+	l.PushBack(1)
+	l.PushBack(2)
+	d := godump.NewDumper(godump.WithContainerSequences())
+	d.Dump(l)
+	// #*list.List [
+	//   0 => 1 #int
+	//   1 => 2 #int
+	// ]
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithCustomBoolStrings renders booleans as t and f instead of true and false.
+	// The colors for true and false values are unchanged.
+
+	// Example: yes/no booleans
+	// Default: "true", "false"
+	type Flags struct {
+		Enabled bool
+		Beta    bool
+	}
+	d := godump.NewDumper(godump.WithCustomBoolStrings("yes", "no"))
+	d.Dump(Flags{Enabled: true})
+	// #godump.Flags {
+	//   +Enabled => yes #bool
+	//   +Beta    => no #bool
+	// }
+}
@@ -0,0 +1,33 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithCycleFullPath adds the path of the original occurrence to each ↩︎ reference,
+	// so cycles and shared pointers show where they point, e.g. ↩︎ &1 ($.Parent).
+	// Paths are rooted at "$" like those passed to WithCallbackOnTruncate.
+
+	// Example: show where a cycle points
+	// Default: false
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	a := &Node{Name: "a"}
+	a.Next = &Node{Name: "b", Next: a}
+	d := godump.NewDumper(godump.WithCycleFullPath())
+	d.Dump(a)
+	// #*godump.Node {
+	//   +Name => "a" #string
+	//   +Next => #*godump.Node {
+	//     +Name => "b" #string
+	//     +Next => #*godump.Node {
+	//       +Name => "a" #string
+	//       +Next => ↩︎ &1 ($.Next)
+	//     }
+	//   }
+	// }
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithDepthNumbers prefixes every output line with its nesting depth, e.g. [2], to help
+	// keep track of very deep structures while scrolling.
+
+	// Example: number nesting depth
+	// Default: false
+	type Inner struct {
+		ID int
+	}
+	type Outer struct {
+		Inner Inner
+	}
+	d := godump.NewDumper(godump.WithDepthNumbers())
+	d.Dump(Outer{Inner: Inner{ID: 1}})
+	// [0] #godump.Outer {
+	// [1]   +Inner => #godump.Inner {
+	// [2]     +ID => 1 #int
+	// [1]   }
+	// [0] }
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithDrainChannels renders the items buffered in a channel by receiving them.
+	// This is destructive: the items are consumed and will not reach the channel's
+	// readers, so only use it to debug stuck pipelines, e.g. in tests. Up to max items
+	// are received; the rest stay in the channel. Send-only and empty channels render as usual.
+
+	// Example: inspect a buffered channel
+	// Default: false
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	d := godump.NewDumper(godump.WithDrainChannels())
+	d.Dump(ch)
+	// chan int(0xc000012345) [
+	//   0 => 1 #int
+	//   1 => 2 #int
+	// ] (2 drained)
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithElideFields hides struct fields with exactly these names (case-sensitive) at any depth.
+	// It is a stricter shorthand for WithExcludeFields that ignores WithFieldMatchMode.
+
+	// Example: elide fields
+	// Default: none
+	type User struct {
+		ID       int
+		Password string
+		Token    string
+	}
+	d := godump.NewDumper(
+		godump.WithElideFields("Password", "Token"),
+	)
+	d.Dump(User{ID: 1, Password: "secret", Token: "abc"})
+	// #godump.User {
+	//   +ID => 1 #int
+	// }
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// WithEmptyJSON sets what DumpJSON and DumpJSONStr return when called with no arguments.
+	// Param value must be valid JSON or this will be ignored, and the default error object is kept.
+
+	// Example: return an empty array
+	// Default: {"error": "DumpJSON called with no arguments"}
+	d := godump.NewDumper(godump.WithEmptyJSON("[]"))
+	out := d.DumpJSONStr()
+	fmt.Println(out)
+	// []
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"strings"
+)
+
+func main() {
+	// WithExtraWriter sends an uncolored copy of every Dump, DumpSlice, and Diff to w.
+	// The primary writer keeps colorized output, so a terminal and a log file can both be fed.
+
+	// Example: tee to a log file
+	// Default: none
+	var log strings.Builder
+	d := godump.NewDumper(godump.WithExtraWriter(&log))
+	d.Dump(map[string]int{"a": 1})
+	// #map[string]int {
+	//   a => 1 #int
+	// }
+}
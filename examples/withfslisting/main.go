@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"testing/fstest"
+)
+
+func main() {
+	// WithFSListing renders values implementing fs.FS, such as embed.FS, as a listing of their files.
+	// Each entry shows its path and size; the walk stops after MaxItems entries.
+
+	// Example: list an embedded filesystem
+	// Default: false
+	fsys := fstest.MapFS{"index.html": {Data: []byte("<html></html>")}}
+	d := godump.NewDumper(godump.WithFSListing())
+	d.Dump(fsys)
+	// #map[string]*fstest.MapFile {
+	//   index.html => 13 bytes
+	// }
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+	"log/slog"
+)
+
+func main() {
+	// WithHeaderFormat customizes the source location header written under each record of a
+	// NewSlogHandler handler. fn receives the logging call's file, relative to the working
+	// directory when possible, and line number, and returns the header text. A nil fn
+	// restores the default "<#dump // file:line" header. Dump, DumpStr, and the other dump
+	// functions print no header, so the option has no effect on their output.
+
+	// Example: custom slog header
+	// Default: nil
+	logger := slog.New(godump.NewSlogHandler(godump.WithHeaderFormat(func(file string, line int) string {
+		return fmt.Sprintf("--- %s:%d ---", file, line)
+	})))
+	logger.Info("ready")
+	// 2026-01-02T15:04:05Z INFO ready
+	// --- main.go:12 ---
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithHeadless drops the outer type marker and braces when dumping a single struct,
+	// map, slice, or array, leaving just its contents. This is separate from the source
+	// location header; see WithoutHeader.
+
+	// Example: dump only the fields of a struct
+	// Default: false
+	type User struct {
+		Name string
+		Age  int
+	}
+	d := godump.NewDumper(godump.WithHeadless())
+	d.Dump(User{Name: "Ada", Age: 36})
+	// +Name => "Ada" #string
+	// +Age  => 36 #int
+}
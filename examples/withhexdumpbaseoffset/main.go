@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithHexDumpBaseOffset starts the offset column of byte slice hex dumps at base instead
+	// of 0, e.g. to show real file or packet offsets. Only the displayed offsets change.
+
+	// Example: show file offsets
+	// Default: 0
+	chunk := []byte("hello")
+	d := godump.NewDumper(godump.WithHexDumpBaseOffset(0x1000))
+	d.Dump(chunk)
+	// ([]uint8) (len=5 cap=5) {
+	//   00001000  68 65 6c 6c 6f                                    | hello            |
+	// }
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithHexDumpGroupSize sets how many bytes of a hex dump line are grouped before an
+	// extra space, e.g. 2, 4, or 8. Param n of 0 disables grouping; negative values are ignored.
+
+	// Example: group by 4 bytes
+	// Default: 8
+	chunk := []byte("hello, world")
+	d := godump.NewDumper(godump.WithHexDumpGroupSize(4))
+	d.Dump(chunk)
+	// ([]uint8) (len=12 cap=12) {
+	//   00000000  68 65 6c 6c  6f 2c 20 77  6f 72 6c 64               | hello, world     |
+	// }
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithHideProtoInternals hides the bookkeeping fields of generated protobuf messages.
+	// Messages are detected by their Reset, String, and ProtoReflect methods, so protobuf isn't imported.
+	// Fields like state, sizeCache, unknownFields, XXX_*, and protoimpl-typed fields are skipped.
+
+	// Example: hide protobuf noise
+	// Default: false
+	var msg any // a generated *pb.User{Name: "Alice"}
+	d := godump.NewDumper(godump.WithHideProtoInternals())
+	d.Dump(msg)
+	// #*pb.User {
+	//   +Name => "Alice" #string
+	// }
+}
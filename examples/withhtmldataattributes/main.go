@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithHTMLDataAttributes wraps every value in DumpHTML output with data-type and data-path attributes.
+	// This lets client-side scripts build collapsible trees or link values back to their path.
+
+	// Example: annotate HTML output
+	// Default: false
+	type User struct {
+		Name string
+	}
+	d := godump.NewDumper(godump.WithHTMLDataAttributes())
+	html := d.DumpHTML(User{Name: "Alice"})
+	_ = html
+	// <span data-type="string" data-path="$.Name">...</span>
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithIndentWidth sets how many spaces each nesting level is indented by, in dumps and
+	// in JSON output. Param n must be 0 or greater or this will be ignored.
+
+	// Example: indent with four spaces
+	// Default: 2
+	v := map[string][]int{"a": {1}}
+	d := godump.NewDumper(godump.WithIndentWidth(4))
+	d.Dump(v)
+	// #map[string][]int {
+	//      a => #[]int [
+	//         0 => 1 #int
+	//     ]
+	// }
+}
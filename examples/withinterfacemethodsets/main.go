@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"io"
+)
+
+func main() {
+	// WithInterfaceMethodSets shows the method names of an interface type next to nil values of
+	// that type, e.g. io.Writer(nil) {Write}, so the expected shape is visible.
+	// Only values whose static interface type is known, like struct fields or pointed-to
+	// variables, carry it; a bare nil passed to Dump has no type.
+
+	// Example: show the methods of nil interface fields
+	// Default: false
+	type Sink struct {
+		Out io.Writer
+	}
+	d := godump.NewDumper(godump.WithInterfaceMethodSets())
+	d.Dump(Sink{})
+	// #godump.Sink {
+	//   +Out => io.Writer(nil) {Write}
+	// }
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"encoding/json"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// WithJSONMarshalerRendering renders types implementing json.Marshaler by their indented
+	// MarshalJSON output instead of their fields, so the dump matches what gets serialized.
+	// Values whose MarshalJSON fails are dumped normally.
+
+	// Example: show a custom JSON shape
+	// Default: false
+	v := json.RawMessage(`{"id":1}`)
+	d := godump.NewDumper(godump.WithJSONMarshalerRendering())
+	d.Dump(v)
+	// {
+	//   "id": 1
+	// } #json.RawMessage
+}
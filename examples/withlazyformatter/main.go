@@ -0,0 +1,29 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// WithLazyFormatter renders values of type t as the summary returned by fn.
+	// fn is only called for values that are actually rendered, so expensive summaries are
+	// skipped for anything cut off by depth or item limits.
+
+	// Example: summarize an expensive type
+	// Default: none
+	type Report struct {
+		Rows []int
+	}
+	d := godump.NewDumper(
+		godump.WithLazyFormatter(reflect.TypeOf(Report{}), func(v reflect.Value) string {
+			return fmt.Sprintf("Report(%d rows)", v.FieldByName("Rows").Len())
+		}),
+	)
+	d.Dump(Report{Rows: []int{1, 2, 3}})
+	// Report(3 rows) #godump.Report
+}
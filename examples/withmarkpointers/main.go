@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithMarkPointers prefixes values reached through pointers with one * per pointer level.
+	// This makes *map[string]int and *[]int stand out from the map or slice itself at a glance.
+
+	// Example: mark pointer values
+	// Default: false
+	v := &[]int{1}
+	d := godump.NewDumper(godump.WithMarkPointers())
+	d.Dump(v)
+	// *#*[]int [
+	//   0 => 1 #int
+	// ]
+}
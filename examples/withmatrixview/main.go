@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithMatrixView renders rectangular slices of scalar slices as an aligned grid.
+	// Ragged or non-scalar nested slices keep the regular rendering.
+
+	// Example: render a matrix
+	// Default: false
+	v := [][]int{{1, 2, 3}, {10, 20, 30}}
+	d := godump.NewDumper(godump.WithMatrixView())
+	d.Dump(v)
+	// #[][]int [
+	//   [ 1  2  3]
+	//   [10 20 30]
+	// ]
+}
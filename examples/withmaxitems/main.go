@@ -17,6 +17,6 @@ func main() {
 	// #[]int [
 	//   0 => 1 #int
 	//   1 => 2 #int
-	//   ... (truncated)
+	//   ... (1 more truncated)
 	// ]
 }
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithMaxMapItems limits how many entries of each map are printed, separately from
+	// WithMaxItems, which then only bounds slices and arrays. Every map gets its own budget,
+	// so sibling and nested maps truncate independently.
+	// Param n must be greater than 0 or this will be ignored, and maps will follow WithMaxItems.
+
+	// Example: show fewer map entries than slice items
+	// Default: same as WithMaxItems
+	v := map[string]int{"a": 1, "b": 2, "c": 3}
+	d := godump.NewDumper(godump.WithMaxMapItems(2))
+	d.Dump(v)
+	// #map[string]int {
+	//   a => 1 #int
+	//   b => 2 #int
+	//   ... (1 more truncated)
+	// }
+}
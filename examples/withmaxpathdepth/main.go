@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithMaxPathDepth limits how many struct field hops are followed from the root value.
+	// Unlike WithMaxDepth, slice, array, and map nesting does not count toward the limit.
+	// Param n must be greater than 0 to take effect; the default 0 means unlimited.
+
+	// Example: limit field nesting
+	// Default: 0 (unlimited)
+	type Inner struct {
+		Value int
+	}
+	type Outer struct {
+		Inner Inner
+	}
+	d := godump.NewDumper(godump.WithMaxPathDepth(1))
+	d.Dump(Outer{Inner: Inner{Value: 1}})
+	// #godump.Outer {
+	//   +Inner => ... (max path depth)
+	// }
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"strings"
+)
+
+func main() {
+	// WithMaxWidth wraps output lines that would exceed cols visible columns, continuing them
+	// on the next line one indent level deeper. ANSI color codes don't count toward the width.
+	// Param cols must be greater than 0 or this will be ignored; HTML output is never wrapped.
+
+	// Example: wrap at 40 columns
+	// Default: 0 (no wrapping)
+	v := map[string]string{"query": strings.Repeat("x", 60)}
+	d := godump.NewDumper(godump.WithMaxWidth(40))
+	d.Dump(v)
+	// #map[string]string {
+	//    query => "xxxxxxxxxxxxxxxxxxxxxxxxxxx
+	//      xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+	//      #string
+	// }
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// WithNilFormatter customizes how nil pointers, maps, slices, channels, funcs, and interfaces render.
+	// The function receives the static type of the nil value; its result replaces the default "type(nil)".
+
+	// Example: distinguish nil kinds
+	// Default: type(nil)
+	d := godump.NewDumper(godump.WithNilFormatter(func(t reflect.Type) string {
+		return "<nil " + t.Kind().String() + ">"
+	}))
+	var m map[string]int
+	d.Dump(m)
+	// <nil map>
+}
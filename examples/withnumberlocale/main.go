@@ -0,0 +1,18 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithNumberLocale sets the digit grouping and decimal point characters for integers and
+	// floats, e.g. '.' and ',' for European style. A grouping of 0 disables grouping, and a
+	// decimal of 0 keeps '.'.
+
+	// Example: European number format
+	// Default: no grouping, '.' decimal point
+	d := godump.NewDumper(godump.WithNumberLocale('.', ','))
+	d.Dump(1000000.5)
+	// 1.000.000,500000 #float64
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithObfuscateValues hides real data for sharing dumps in bug reports or screenshots.
+	// Strings become runs of "x" of the same length, digits become "9" so numbers keep
+	// their magnitude, and Stringer output is masked the same way. Field names, map keys,
+	// and types are kept, and the output is deterministic, so repeated dumps match.
+
+	// Example: share a dump without its data
+	// Default: false
+	type User struct {
+		Email string
+		Age   int
+	}
+	d := godump.NewDumper(godump.WithObfuscateValues())
+	d.Dump(User{Email: "ada@example.com", Age: 36})
+	// #godump.User {
+	//   +Email => "xxxxxxxxxxxxxxx" #string
+	//   +Age   => 99 #int
+	// }
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithOneBasedIndices numbers slice and array elements starting at 1 instead of 0.
+	// This only changes the displayed labels; paths and selectors stay zero-based.
+
+	// Example: one-based indices
+	// Default: false
+	v := []string{"a", "b"}
+	d := godump.NewDumper(godump.WithOneBasedIndices())
+	d.Dump(v)
+	// #[]string [
+	//   1 => "a" #string
+	//   2 => "b" #string
+	// ]
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithOnlyNonDefault renders only struct fields whose values differ from their zero value.
+	// Fields are compared recursively, so empty slices and maps, pointers to zero values, and
+	// nested structs with only zero fields are hidden as well. Useful for seeing what a config overrides.
+
+	// Example: show overridden config fields
+	// Default: false
+	type Config struct {
+		Host    string
+		Port    int
+		Debug   bool
+		Plugins []string
+	}
+	d := godump.NewDumper(godump.WithOnlyNonDefault())
+	d.Dump(Config{Port: 8080, Plugins: []string{}})
+	// #godump.Config {
+	//   +Port => 8080 #int
+	// }
+}
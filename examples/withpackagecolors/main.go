@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"time"
+)
+
+func main() {
+	// WithPackageColors colors #pkg.Type markers by package, so types from the same package share a color.
+	// Colors come from the theme's PackageColors, keyed by a hash of the package path, so they are stable across runs.
+
+	// Example: color types by package
+	// Default: false
+	v := map[string]time.Duration{"a": time.Second}
+	d := godump.NewDumper(godump.WithPackageColors())
+	d.Dump(v)
+	// #map[string]time.Duration {
+	//   a => 1s #time.Duration
+	// }
+}
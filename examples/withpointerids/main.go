@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithPointerIDs labels every pointer with an &N anchor the first time it is printed.
+	// Later occurrences of the same pointer render as ↩︎ &N, so aliased fields are easy to spot.
+
+	// Example: label pointers
+	// Default: false
+	type Pair struct {
+		A, B *int
+	}
+	n := 1
+	d := godump.NewDumper(godump.WithPointerIDs())
+	d.Dump(Pair{A: &n, B: &n})
+	// #godump.Pair {
+	//   +A => &1 1 #*int
+	//   +B => ↩︎ &1
+	// }
+}
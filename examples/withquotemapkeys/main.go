@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithQuoteMapKeys controls whether string map keys are rendered in double quotes.
+	// Slice and field string values are always quoted; this only affects map keys.
+
+	// Example: quote map keys
+	// Default: false
+	v := map[string]int{"a b": 1}
+	d := godump.NewDumper(godump.WithQuoteMapKeys(true))
+	d.Dump(v)
+	// #map[string]int {
+	//    "a b" => 1 #int
+	// }
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"time"
+)
+
+func main() {
+	// WithRawMode renders every value by its underlying kind, showing the actual fields.
+	// It bypasses fmt.Stringer and all built-in type formatters.
+
+	// Example: show raw structure
+	// Default: false
+	v := time.Duration(3)
+	d := godump.NewDumper(godump.WithRawMode())
+	d.Dump(v)
+	// 3 #time.Duration
+}
@@ -0,0 +1,37 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// WithRecursionLimitCallback registers fn to be called when a value is about to be cut off
+	// by WithMaxDepth. The path (rooted at "$") and type of that value are passed; returning
+	// n > 0 allows n more levels below it, while 0 truncates as usual.
+
+	// Example: dig deeper only into one type
+	// Default: nil
+	type Node struct {
+		Next *Node
+	}
+	nodeType := reflect.TypeOf(&Node{})
+	d := godump.NewDumper(
+		godump.WithMaxDepth(1),
+		godump.WithRecursionLimitCallback(func(path string, t reflect.Type) int {
+			if t == nodeType {
+				return 1
+			}
+			return 0
+		}),
+	)
+	d.Dump(&Node{Next: &Node{}})
+	// #*godump.Node {
+	//   +Next => #*godump.Node {
+	//     +Next => *godump.Node(nil)
+	//   }
+	// }
+}
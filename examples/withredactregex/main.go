@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithRedactRegex redacts struct fields and string map keys whose name matches the pattern.
+	// The pattern is compiled once when the option is created and panics if it is invalid,
+	// like regexp.MustCompile, since dumper options are expected to be static.
+
+	// Example: redact by pattern
+	// Default: none
+	type Config struct {
+		DBSecret string
+		Host     string
+	}
+	d := godump.NewDumper(
+		godump.WithRedactRegex(`(?i)secret`),
+	)
+	d.Dump(Config{DBSecret: "hunter2", Host: "localhost"})
+	// #godump.Config {
+	//   +DBSecret => <redacted> #string
+	//   +Host     => "localhost" #string
+	// }
+}
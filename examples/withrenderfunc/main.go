@@ -0,0 +1,31 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// WithRenderFunc registers fn to be called for every value before any other formatting.
+	// Returning ok=true prints the returned string in place of the value and its whole
+	// subtree; returning false renders the value normally. indent is the nesting depth,
+	// for aligning multi-line output. Unlike type formatters, fn can decide per value.
+
+	// Example: mask long strings
+	// Default: nil
+	d := godump.NewDumper(godump.WithRenderFunc(func(v reflect.Value, indent int) (string, bool) {
+		if v.Kind() == reflect.String && v.Len() > 8 {
+			return fmt.Sprintf("<%d chars>", v.Len()), true
+		}
+		return "", false
+	}))
+	d.Dump([]string{"short", "a much longer string"})
+	// #[]string [
+	//   0 => "short" #string
+	//   1 => <20 chars>
+	// ]
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithRenderZeroPointersAsNil renders non-nil pointers to zero values inline as
+	// *Type(zero) instead of expanding the pointee. Nil pointers are unaffected.
+
+	// Example: collapse pointers to zero values
+	// Default: false
+	type Limits struct {
+		Max int
+	}
+	type Config struct {
+		Limits *Limits
+	}
+	d := godump.NewDumper(godump.WithRenderZeroPointersAsNil())
+	d.Dump(Config{Limits: &Limits{}})
+	// #godump.Config {
+	//   +Limits => *godump.Limits(zero)
+	// }
+}
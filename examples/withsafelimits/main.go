@@ -0,0 +1,33 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"encoding/json"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// WithSafeLimits applies conservative caps for dumping untrusted input, such as
+	// deserialized payloads, so a deeply nested or huge value cannot stall the dumper.
+	// It limits depth to 8, collections to 50 items, strings to 1024 runes, the walk
+	// to 10000 values, and the output to 1 MiB, and stops the walk after one second.
+	// Maps larger than the node cap are sampled instead of read in full, and the caps
+	// also bound DumpSlice and DumpPath.
+	// Options given after it override the individual depth, item, and string limits.
+
+	// Example: dump an untrusted payload
+	// Default: unlimited nodes, output, and time
+	var payload any
+	_ = json.Unmarshal([]byte(`{"a":[1,2,3]}`), &payload)
+	d := godump.NewDumper(godump.WithSafeLimits())
+	d.Dump(payload)
+	// #map[string]interface {} {
+	//   a => #[]interface {} [
+	//     0 => 1 #float64
+	//     1 => 2 #float64
+	//     2 => 3 #float64
+	//   ]
+	// }
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithSampleLargeCollections shows the first and last elements of long slices and arrays.
+	// Slices and arrays longer than n print n/2 leading and n/2 trailing elements around an omitted marker,
+	// replacing the MaxItems cut-off. Maps have no order, so they show their first n entries.
+	// Param n must be greater than 0 to take effect.
+
+	// Example: sample a long slice
+	// Default: 0 (disabled)
+	v := []int{1, 2, 3, 4, 5, 6}
+	d := godump.NewDumper(godump.WithSampleLargeCollections(2))
+	d.Dump(v)
+	// #[]int [
+	//   0 => 1 #int
+	//   ... (4 omitted)
+	//   5 => 6 #int
+	// ]
+}
@@ -0,0 +1,28 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/godump"
+)
+
+func main() {
+	// WithSemanticColors colors values by meaning in addition to type: errors render their
+	// message in red, true renders green, and zero values such as 0, "", and false are dimmed.
+	// The colors come from the theme's ErrorColor, BoolColor, and ZeroColor.
+
+	// Example: highlight errors and zero values
+	// Default: false
+	type Result struct {
+		OK  bool
+		Err error
+	}
+	d := godump.NewDumper(godump.WithSemanticColors())
+	d.Dump(Result{Err: fmt.Errorf("timeout")})
+	// #godump.Result {
+	//   +OK  => false #bool
+	//   +Err => timeout #*errors.errorString
+	// }
+}
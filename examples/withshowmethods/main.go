@@ -0,0 +1,28 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"strings"
+)
+
+func main() {
+	// WithShowMethods lists the exported method set of structs in a methods: section after
+	// their fields. Structs reached through a pointer list the pointer's method set.
+	// The list is bounded by WithMaxItems.
+
+	// Example: list methods
+	// Default: false
+	d := godump.NewDumper(godump.WithShowMethods())
+	d.Dump(&strings.Builder{})
+	// #*strings.Builder {
+	//   -addr => *strings.Builder(nil)
+	//   -buf  => []uint8(nil)
+	//   methods:
+	//     Cap() int
+	//     Grow(int)
+	//     ...
+	// }
+}
@@ -0,0 +1,33 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"net/url"
+	"sync"
+)
+
+func main() {
+	// WithSkipStdlibInternals renders common standard library types readably instead of
+	// exposing their unexported internals. On top of the types godump always formats
+	// (time.Time, time.Duration, time.Timer, time.Ticker, netip, and *regexp.Regexp), it covers:
+	//   - sync.Mutex, sync.RWMutex, sync.WaitGroup, and sync.Once as placeholders
+	//   - url.URL values as their string form
+	//   - reflect.Value as its scalar value or type
+	//   - container/list and container/ring as element sequences (see WithContainerSequences)
+
+	// Example: hide stdlib internals
+	// Default: false
+	type Service struct {
+		mu       sync.Mutex
+		Endpoint url.URL
+	}
+	d := godump.NewDumper(godump.WithSkipStdlibInternals())
+	d.Dump(&Service{Endpoint: url.URL{Scheme: "https", Host: "example.com"}})
+	// #*godump.Service {
+	//   -mu       => sync.Mutex{…} #sync.Mutex
+	//   +Endpoint => https://example.com #url.URL
+	// }
+}
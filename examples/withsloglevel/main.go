@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"log/slog"
+)
+
+func main() {
+	// WithSlogLevel sets the minimum level of records written by a handler from
+	// NewSlogHandler. Passing a *slog.LevelVar lets the level change at runtime.
+
+	// Example: include debug records
+	// Default: slog.LevelInfo
+	logger := slog.New(godump.NewSlogHandler(godump.WithSlogLevel(slog.LevelDebug)))
+	logger.Debug("cache miss", "key", "user:1")
+	// 2026-01-02T15:04:05Z DEBUG cache miss
+	//   key => "user:1" #string
+}
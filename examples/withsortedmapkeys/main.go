@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithSortedMapKeys controls whether map entries are printed in sorted key order, so the
+	// same map always dumps the same way. Numeric and string keys sort by value, and other
+	// keys by their rendered form. Pass false to keep Go's randomized iteration order.
+
+	// Example: keep raw map order
+	// Default: true
+	v := map[string]int{"b": 2, "a": 1}
+	d := godump.NewDumper(godump.WithSortedMapKeys(false))
+	d.Dump(v)
+	// #map[string]int {
+	//   b => 2 #int
+	//   a => 1 #int
+	// }
+}
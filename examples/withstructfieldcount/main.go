@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithStructFieldCount shows how many fields a struct renders next to its type name.
+	// The count reflects field filters such as WithExcludeFields.
+
+	// Example: show field counts
+	// Default: false
+	type User struct {
+		ID   int
+		Name string
+	}
+	d := godump.NewDumper(godump.WithStructFieldCount())
+	d.Dump(User{ID: 1, Name: "Alice"})
+	// #godump.User (2 fields) {
+	//   +ID   => 1 #int
+	//   +Name => "Alice" #string
+	// }
+}
@@ -0,0 +1,18 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithTheme sets the colors used for each role in a dump, such as strings, numbers,
+	// and type markers. Start from DefaultTheme to change only a few roles.
+
+	// Example: custom string color
+	// Default: DefaultTheme()
+	theme := godump.DefaultTheme()
+	theme.StringColor = "\033[34m"
+	d := godump.NewDumper(godump.WithTheme(theme))
+	_ = d
+}
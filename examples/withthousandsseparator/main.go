@@ -0,0 +1,17 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithThousandsSeparator groups the digits of integers and the integer part of floats.
+	// Param sep is inserted between every group of three digits; 0 disables grouping.
+
+	// Example: group digits
+	// Default: disabled
+	d := godump.NewDumper(godump.WithThousandsSeparator(','))
+	d.Dump(1000000)
+	// 1,000,000 #int
+}
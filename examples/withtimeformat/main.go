@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"time"
+)
+
+func main() {
+	// WithTimeFormat renders time.Time values with the given layout wherever they appear,
+	// including inside slices, arrays, and maps, instead of their String form.
+	// The zero time renders as time.Time(zero).
+
+	// Example: show times as RFC 3339
+	// Default: "" (time.Time.String)
+	v := []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	d := godump.NewDumper(godump.WithTimeFormat(time.RFC3339))
+	d.Dump(v)
+	// #[]time.Time [
+	//   0 => 2024-01-02T03:04:05Z #time.Time
+	// ]
+}
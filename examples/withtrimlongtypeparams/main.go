@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"sync/atomic"
+)
+
+func main() {
+	// WithTrimLongTypeParams abbreviates generic type arguments in type markers once the
+	// argument list is longer than n characters, keeping the base type name and any leading
+	// arguments that fit, e.g. #godump.Cache[string, …]. Param n must be greater than 0 or this will be ignored.
+
+	// Example: shorten generic type markers
+	// Default: 0 (no trimming)
+	var p atomic.Pointer[map[string][]int]
+	d := godump.NewDumper(godump.WithTrimLongTypeParams(10))
+	d.Dump(&p)
+	// #*atomic.Pointer[…] {
+	//   ...
+	// }
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import "github.com/goforj/godump"
+
+func main() {
+	// WithTrueColor renders colors with 24-bit ANSI sequences for terminals that support them.
+	// It has no effect when colors are disabled, including through NO_COLOR.
+
+	// Example: enable truecolor output
+	// Default: false (256-color palette, unless COLORTERM=truecolor)
+	v := map[string]int{"a": 1}
+	d := godump.NewDumper(godump.WithTrueColor())
+	d.Dump(v)
+	// #map[string]int {
+	//   a => 1 #int
+	// }
+}
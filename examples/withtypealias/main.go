@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"github.com/goforj/godump"
+	"reflect"
+)
+
+func main() {
+	// WithTypeAlias displays alias instead of the full name of t in type markers.
+	// Composite types built from t, such as slices or pointers, use the alias for their element.
+
+	// Example: shorten a verbose type name
+	// Default: none
+	type VeryLongGeneratedResponseEnvelope struct {
+		ID int
+	}
+	d := godump.NewDumper(
+		godump.WithTypeAlias(reflect.TypeOf(VeryLongGeneratedResponseEnvelope{}), "Envelope"),
+	)
+	d.Dump(VeryLongGeneratedResponseEnvelope{ID: 1})
+	// #Envelope {
+	//   +ID => 1 #int
+	// }
+}
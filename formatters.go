@@ -0,0 +1,507 @@
+package godump
+
+import (
+	"container/list"
+	"container/ring"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	netipAddrType     = reflect.TypeOf(netip.Addr{})
+	netipPrefixType   = reflect.TypeOf(netip.Prefix{})
+	netipAddrPortType = reflect.TypeOf(netip.AddrPort{})
+	regexpPtrType     = reflect.TypeOf((*regexp.Regexp)(nil))
+	listType          = reflect.TypeOf(list.List{})
+	listPtrType       = reflect.TypeOf((*list.List)(nil))
+	ringPtrType       = reflect.TypeOf((*ring.Ring)(nil))
+	timerType         = reflect.TypeOf(time.Timer{})
+	timerPtrType      = reflect.TypeOf((*time.Timer)(nil))
+	tickerType        = reflect.TypeOf(time.Ticker{})
+	tickerPtrType     = reflect.TypeOf((*time.Ticker)(nil))
+	mutexType         = reflect.TypeOf(sync.Mutex{})
+	rwMutexType       = reflect.TypeOf(sync.RWMutex{})
+	waitGroupType     = reflect.TypeOf(sync.WaitGroup{})
+	onceType          = reflect.TypeOf(sync.Once{})
+	urlType           = reflect.TypeOf(url.URL{})
+	reflectValueType  = reflect.TypeOf(reflect.Value{})
+	flagSetType       = reflect.TypeOf(flag.FlagSet{})
+	timeType          = reflect.TypeOf(time.Time{})
+	timePtrType       = reflect.TypeOf((*time.Time)(nil))
+	flagSetPtrType    = reflect.TypeOf((*flag.FlagSet)(nil))
+	filePtrType       = reflect.TypeOf((*os.File)(nil))
+)
+
+// formatKnownType renders standard library types whose reflected structure is noise.
+// It returns false when v is not one of the handled types.
+func (d *Dumper) formatKnownType(v reflect.Value) (string, bool) {
+	switch v.Type() {
+	case netipAddrType, netipPrefixType, netipAddrPortType:
+		return d.formatNetIP(v), true
+	case regexpPtrType:
+		return d.formatRegexp(v), true
+	case timerType, timerPtrType, tickerType, tickerPtrType:
+		return d.formatTimer(v), true
+	case filePtrType:
+		return d.formatFile(v), true
+	case timeType, timePtrType:
+		if d.timeFormat != "" {
+			return d.formatTime(v), true
+		}
+	}
+	if d.skipStdlibInternals {
+		return d.formatStdlibInternal(v)
+	}
+	return "", false
+}
+
+// formatStdlibInternal renders the stdlib types covered by WithSkipStdlibInternals.
+func (d *Dumper) formatStdlibInternal(v reflect.Value) (string, bool) {
+	marker := d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type()))
+	switch v.Type() {
+	case mutexType, rwMutexType, waitGroupType, onceType:
+		return d.colorize(d.theme.TypeColor, v.Type().String()+"{…}") + marker, true
+	case urlType:
+		u, _ := forceExported(v).Interface().(url.URL)
+		return d.colorize(d.theme.StringColor, d.obfuscateText(u.String())) + marker, true
+	case reflectValueType:
+		rv, _ := forceExported(v).Interface().(reflect.Value)
+		return d.colorize(d.theme.StringColor, "reflect.Value("+d.reflectValueSummary(rv)+")") + marker, true
+	}
+	return "", false
+}
+
+// reflectValueSummary describes a reflect.Value by its scalar value or its type.
+func (d *Dumper) reflectValueSummary(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return "<invalid>"
+	}
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return d.inlineValue(rv) + " " + d.getTypeString(rv.Type())
+	}
+	return d.getTypeString(rv.Type())
+}
+
+// formatNetIP renders netip values by their textual form, and zero values as "invalid".
+func (d *Dumper) formatNetIP(v reflect.Value) string {
+	var text string
+	var valid bool
+	switch val := forceExported(v).Interface().(type) {
+	case netip.Addr:
+		text, valid = val.String(), val.IsValid()
+	case netip.Prefix:
+		text, valid = val.String(), val.IsValid()
+	case netip.AddrPort:
+		text, valid = val.String(), val.IsValid()
+	}
+
+	typeStr := d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type()))
+	if !valid {
+		return d.colorize(d.theme.NullColor, "invalid") + typeStr
+	}
+	return d.colorize(d.theme.StringColor, d.obfuscateText(text)) + typeStr
+}
+
+// formatRegexp renders a compiled regexp as the call that would recreate it.
+func (d *Dumper) formatRegexp(v reflect.Value) string {
+	re, _ := forceExported(v).Interface().(*regexp.Regexp)
+	return d.colorize(d.theme.StringColor, "regexp.MustCompile("+strconv.Quote(d.obfuscateText(re.String()))+")") +
+		d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type()))
+}
+
+// formatTime renders a time.Time or *time.Time with the WithTimeFormat layout,
+// and the zero time as time.Time(zero).
+func (d *Dumper) formatTime(v reflect.Value) string {
+	typ := v.Type()
+	v = forceExported(v)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	t, _ := v.Interface().(time.Time)
+	if t.IsZero() {
+		return d.colorize(d.theme.NullColor, "time.Time(zero)") +
+			d.colorize(d.typeColor(typ), " #"+d.getTypeString(typ))
+	}
+	return d.colorize(d.theme.StringColor, d.obfuscateText(t.Format(d.timeFormat))) +
+		d.colorize(d.typeColor(typ), " #"+d.getTypeString(typ))
+}
+
+// formatTimer renders time.Timer and time.Ticker as placeholders, since their runtime
+// internals aren't meaningfully inspectable and change between Go versions.
+func (d *Dumper) formatTimer(v reflect.Value) string {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return d.colorize(d.theme.TypeColor, t.String()+"{…}") +
+		d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type()))
+}
+
+// formatFile renders an *os.File by its name and descriptor, e.g. os.File(name=/tmp/x.txt fd=3).
+// Both are read through reflection so dumping never calls Fd, which switches the file to
+// blocking mode; a closed file shows "closed" in place of the descriptor.
+func (d *Dumper) formatFile(v reflect.Value) string {
+	parts := []string{}
+	// *os.File wraps an unexported *os.file holding the name and a poll.FD.
+	inner := v.Elem().FieldByName("file")
+	if inner.Kind() == reflect.Ptr && !inner.IsNil() {
+		inner = inner.Elem()
+		if name := inner.FieldByName("name"); name.Kind() == reflect.String {
+			parts = append(parts, "name="+d.obfuscateText(name.String()))
+		}
+		if pfd := inner.FieldByName("pfd"); pfd.Kind() == reflect.Struct {
+			if fd := pfd.FieldByName("Sysfd"); fd.CanInt() {
+				if fd.Int() < 0 {
+					parts = append(parts, "closed")
+				} else {
+					parts = append(parts, "fd="+strconv.FormatInt(fd.Int(), 10))
+				}
+			}
+		}
+	}
+	return d.colorize(d.theme.StringColor, "os.File("+strings.Join(parts, " ")+")") +
+		d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type()))
+}
+
+// errStopWalk ends an fs.WalkDir early once maxItems entries have been listed.
+var errStopWalk = errors.New("godump: stop walk")
+
+var fsType = reflect.TypeOf((*fs.FS)(nil)).Elem()
+
+// printFS renders an fs.FS as a listing of its files and sizes when WithFSListing is enabled.
+// It returns false when v does not implement fs.FS.
+func (d *Dumper) printFS(w io.Writer, v reflect.Value, indent int) bool {
+	if !d.fsListing || !v.Type().Implements(fsType) {
+		return false
+	}
+	fsys, ok := forceExported(v).Interface().(fs.FS)
+	if !ok {
+		return false
+	}
+
+	fmt.Fprintf(w, "%s {", d.colorize(d.theme.TypeColor, "#"+d.getTypeString(v.Type())))
+	fmt.Fprintln(w)
+
+	count := 0
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if path == "." && err == nil {
+			return nil
+		}
+		if count >= d.maxItems {
+			d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, "... (truncated)"))
+			fmt.Fprintln(w)
+			return errStopWalk
+		}
+		count++
+
+		d.indentPrint(w, indent+1, d.colorize(d.theme.KeyColor, path)+" => ")
+		switch {
+		case err != nil:
+			fmt.Fprint(w, d.colorize(d.theme.ErrorColor, "<error: "+err.Error()+">"))
+		case entry.IsDir():
+			fmt.Fprint(w, d.colorize(d.theme.MetaColor, "(dir)"))
+		default:
+			if info, infoErr := entry.Info(); infoErr == nil {
+				fmt.Fprint(w, d.colorize(d.theme.NumberColor, d.groupDigits(fmt.Sprint(info.Size())))+d.colorize(d.theme.MetaColor, " bytes"))
+			} else {
+				fmt.Fprint(w, d.colorize(d.theme.ErrorColor, "<error: "+infoErr.Error()+">"))
+			}
+		}
+		fmt.Fprintln(w)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopWalk) {
+		d.indentPrint(w, indent+1, d.colorize(d.theme.ErrorColor, "<error: "+err.Error()+">"))
+		fmt.Fprintln(w)
+	}
+
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "}")
+	return true
+}
+
+// protoInternalFields are the unexported bookkeeping fields protoc-gen-go adds to messages.
+var protoInternalFields = map[string]bool{
+	"state":           true,
+	"sizeCache":       true,
+	"unknownFields":   true,
+	"extensionFields": true,
+	"weakFields":      true,
+}
+
+// isProtoMessage reports whether t or *t has the proto.Message-style method set
+// (Reset, String, ProtoReflect) without depending on the protobuf module.
+func isProtoMessage(t reflect.Type) bool {
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		_, hasReset := candidate.MethodByName("Reset")
+		_, hasString := candidate.MethodByName("String")
+		_, hasReflect := candidate.MethodByName("ProtoReflect")
+		if hasReset && hasString && hasReflect {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtoInternalField reports whether a message field is generated bookkeeping rather than data.
+func isProtoInternalField(f reflect.StructField) bool {
+	if strings.HasPrefix(f.Name, "XXX_") || protoInternalFields[f.Name] {
+		return true
+	}
+	ft := f.Type
+	for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice {
+		ft = ft.Elem()
+	}
+	return strings.HasSuffix(ft.PkgPath(), "/protoimpl")
+}
+
+// printContainer renders container/list and container/ring values as element sequences
+// when WithContainerSequences is enabled. It returns false for other types.
+func (d *Dumper) printContainer(w io.Writer, v reflect.Value, indent int, state *dumpState) bool {
+	if !d.containerSequences {
+		return false
+	}
+
+	var values []any
+	truncated := false
+	switch v.Type() {
+	case listType, listPtrType:
+		l := containerList(v)
+		if l == nil {
+			return false
+		}
+		for e := l.Front(); e != nil; e = e.Next() {
+			if len(values) >= d.maxItems {
+				truncated = true
+				break
+			}
+			values = append(values, e.Value)
+		}
+	case ringPtrType:
+		r, _ := forceExported(v).Interface().(*ring.Ring)
+		for p := r; ; {
+			if len(values) >= d.maxItems {
+				truncated = true
+				break
+			}
+			values = append(values, p.Value)
+			if p = p.Next(); p == r {
+				break
+			}
+		}
+	default:
+		return false
+	}
+
+	fmt.Fprintf(w, "%s [", d.colorize(d.typeColor(v.Type()), "#"+d.getTypeString(v.Type())))
+	fmt.Fprintln(w)
+	for i, val := range values {
+		d.indentPrint(w, indent+1, fmt.Sprintf("%s => ", d.colorize(d.theme.NumberColor, fmt.Sprintf("%d", i))))
+		state.pushIndex(i)
+		d.printValue(w, reflect.ValueOf(val), indent+1, state)
+		state.popPath()
+		fmt.Fprintln(w)
+	}
+	if truncated {
+		d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, "... (truncated)"))
+		fmt.Fprintln(w)
+	}
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "]")
+	return true
+}
+
+// printFlagSet renders a flag.FlagSet as its defined flags in lexical order, e.g.
+// port=8080 (default=80, usage="listen port"), instead of its internal maps.
+// It returns false for other types.
+func (d *Dumper) printFlagSet(w io.Writer, v reflect.Value, indent int) bool {
+	if v.Type() != flagSetType && v.Type() != flagSetPtrType {
+		return false
+	}
+	v = forceExported(v)
+	if v.Kind() != reflect.Ptr {
+		if !v.CanAddr() {
+			return false
+		}
+		v = v.Addr()
+	}
+	fs, _ := v.Interface().(*flag.FlagSet)
+
+	fmt.Fprintf(w, "%s {", d.colorize(d.typeColor(v.Type()), "#"+d.getTypeString(v.Type())))
+	fmt.Fprintln(w)
+	count := 0
+	fs.VisitAll(func(f *flag.Flag) {
+		if count == d.maxItems {
+			d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, "... (truncated)"))
+			fmt.Fprintln(w)
+		}
+		count++
+		if count > d.maxItems {
+			return
+		}
+		d.indentPrint(w, indent+1, d.colorize(d.theme.KeyColor, f.Name)+"="+d.colorize(d.theme.StringColor, f.Value.String()))
+		fmt.Fprint(w, d.colorize(d.theme.MetaColor, fmt.Sprintf(" (default=%s, usage=%s)", f.DefValue, strconv.Quote(f.Usage))))
+		fmt.Fprintln(w)
+	})
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "}")
+	return true
+}
+
+// containerList returns the *list.List behind v, or nil when a list value isn't addressable.
+func containerList(v reflect.Value) *list.List {
+	v = forceExported(v)
+	if v.Kind() == reflect.Ptr {
+		l, _ := v.Interface().(*list.List)
+		return l
+	}
+	if !v.CanAddr() {
+		return nil
+	}
+	l, _ := v.Addr().Interface().(*list.List)
+	return l
+}
+
+var rangeFuncType = reflect.TypeOf(func(func(any, any) bool) {})
+
+// rangeMethod returns the bound Range(func(key, value any) bool) method of v, looking at
+// the pointer method set when v is addressable, or an invalid Value when there is none.
+func rangeMethod(v reflect.Value) reflect.Value {
+	v = forceExported(v)
+	if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface && v.CanAddr() {
+		v = v.Addr()
+	}
+	m := v.MethodByName("Range")
+	if !m.IsValid() || m.Type() != rangeFuncType {
+		return reflect.Value{}
+	}
+	return m
+}
+
+// printRangeMap renders types with a Range(func(key, value any) bool) method, such as
+// sync.Map, as maps. Entries are sorted by key and bounded by mapItemLimit.
+func (d *Dumper) printRangeMap(w io.Writer, v reflect.Value, indent int, state *dumpState) bool {
+	if v.Kind() == reflect.Map {
+		return false
+	}
+	m := rangeMethod(v)
+	if !m.IsValid() {
+		return false
+	}
+
+	// Collect every entry before truncating, so the kept ones are the first keys in
+	// sorted order rather than whichever Range happened to yield first.
+	var entries []mapEntry
+	m.Call([]reflect.Value{reflect.ValueOf(func(k, val any) bool {
+		entries = append(entries, mapEntry{key: d.formatMapKey(reflect.ValueOf(k)), value: reflect.ValueOf(val)})
+		return true
+	})})
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	total := len(entries)
+	if total > d.mapItemLimit() {
+		entries = entries[:d.mapItemLimit()]
+	}
+	d.printMapEntries(w, v.Type(), entries, total, indent, state)
+	return true
+}
+
+// orderedMapMethods returns the bound Keys and Get methods of an ordered map type: Keys()
+// must return a slice, and Get must take one of its elements and return the value,
+// optionally followed by a bool. Invalid Values are returned when v has no such pair.
+func orderedMapMethods(v reflect.Value) (keys, get reflect.Value) {
+	v = forceExported(v)
+	if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface && v.CanAddr() {
+		v = v.Addr()
+	}
+	keys, get = v.MethodByName("Keys"), v.MethodByName("Get")
+	if !keys.IsValid() || !get.IsValid() {
+		return reflect.Value{}, reflect.Value{}
+	}
+	kt, gt := keys.Type(), get.Type()
+	if kt.NumIn() != 0 || kt.NumOut() != 1 || kt.Out(0).Kind() != reflect.Slice {
+		return reflect.Value{}, reflect.Value{}
+	}
+	if gt.NumIn() != 1 || !kt.Out(0).Elem().AssignableTo(gt.In(0)) {
+		return reflect.Value{}, reflect.Value{}
+	}
+	if gt.NumOut() != 1 && (gt.NumOut() != 2 || gt.Out(1).Kind() != reflect.Bool) {
+		return reflect.Value{}, reflect.Value{}
+	}
+	return keys, get
+}
+
+// printOrderedMap renders ordered map types exposing Keys() []K and Get(K) V (or
+// Get(K) (V, bool)) as maps in the order Keys returns, bounded by mapItemLimit.
+func (d *Dumper) printOrderedMap(w io.Writer, v reflect.Value, indent int, state *dumpState) bool {
+	if v.Kind() == reflect.Map {
+		return false
+	}
+	keysFn, get := orderedMapMethods(v)
+	if !keysFn.IsValid() {
+		return false
+	}
+
+	keys := keysFn.Call(nil)[0]
+	var entries []mapEntry
+	for i := 0; i < keys.Len() && i < d.mapItemLimit(); i++ {
+		k := keys.Index(i)
+		entries = append(entries, mapEntry{key: d.formatMapKey(k), value: get.Call([]reflect.Value{k})[0]})
+	}
+
+	d.printMapEntries(w, v.Type(), entries, keys.Len(), indent, state)
+	return true
+}
+
+// mapEntry is a rendered key and its value, for map-like types printed as maps.
+type mapEntry struct {
+	key   string
+	value reflect.Value
+}
+
+// printMapEntries prints entries as a map of type t, aligning their keys. total is the
+// size of the full map; when it exceeds len(entries) a truncation marker is printed.
+func (d *Dumper) printMapEntries(w io.Writer, t reflect.Type, entries []mapEntry, total int, indent int, state *dumpState) {
+	keyWidth := 0
+	for _, e := range entries {
+		if n := utf8.RuneCountInString(e.key); n > keyWidth {
+			keyWidth = n
+		}
+	}
+
+	fmt.Fprintf(w, "%s {", d.colorize(d.typeColor(t), "#"+d.getTypeString(t)))
+	fmt.Fprintln(w)
+	for _, e := range entries {
+		pad := strings.Repeat(" ", keyWidth-utf8.RuneCountInString(e.key))
+		d.indentPrint(w, indent+1, fmt.Sprintf(" %s%s => ", d.colorize(d.theme.KeyColor, e.key), pad))
+		state.pushKey(e.key)
+		d.printValue(w, e.value, indent+1, state)
+		state.popPath()
+		fmt.Fprintln(w)
+	}
+	if total > len(entries) {
+		d.reportTruncation(state, "map", total)
+		d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, "... (truncated)"))
+		fmt.Fprintln(w)
+	}
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "}")
+}
@@ -0,0 +1,391 @@
+package godump
+
+import (
+	"container/list"
+	"container/ring"
+	"errors"
+	"flag"
+	"io/fs"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	assert "github.com/goforj/godump/internal/testassert"
+)
+
+func TestNetIPFormatting(t *testing.T) {
+	type Route struct {
+		Gateway netip.Addr
+		Network netip.Prefix
+		Unset   netip.Addr
+	}
+
+	route := Route{
+		Gateway: netip.MustParseAddr("2001:db8::1"),
+		Network: netip.MustParsePrefix("10.0.0.0/8"),
+	}
+
+	for _, d := range []*Dumper{newDumperT(t), newDumperT(t, WithDisableStringer(true))} {
+		out := d.DumpStr(route)
+		assert.Contains(t, out, "2001:db8::1 #netip.Addr")
+		assert.Contains(t, out, "10.0.0.0/8 #netip.Prefix")
+		assert.Contains(t, out, "invalid #netip.Addr")
+		assert.NotContains(t, out, "invalid IP")
+		assert.NotContains(t, out, "addr")
+	}
+
+	assert.Contains(t, dumpStrT(t, netip.Addr{}), "invalid #netip.Addr")
+	assert.Contains(t, dumpStrT(t, netip.MustParseAddrPort("1.2.3.4:80")), "1.2.3.4:80 #netip.AddrPort")
+}
+
+func TestRegexpFormatting(t *testing.T) {
+	type Route struct {
+		Pattern *regexp.Regexp
+		Unset   *regexp.Regexp
+	}
+
+	out := dumpStrT(t, Route{Pattern: regexp.MustCompile(`^/users/(\d+)$`)})
+	assert.Contains(t, out, `regexp.MustCompile("^/users/(\\d+)$") #*regexp.Regexp`)
+	assert.Contains(t, out, "*regexp.Regexp(nil)")
+	assert.NotContains(t, out, "prog")
+	assert.NotContains(t, out, "onepass")
+
+	out = newDumperT(t, WithDisableStringer(true)).DumpStr(regexp.MustCompile("a+"))
+	assert.Contains(t, out, `regexp.MustCompile("a+")`)
+}
+
+type brokenFS struct{}
+
+func (brokenFS) Open(name string) (fs.File, error) {
+	return nil, errors.New("boom")
+}
+
+func TestFSListing(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":     {Data: []byte("<html></html>")},
+		"static/app.js":  {Data: []byte("console.log(1)")},
+		"static/app.css": {Data: []byte("")},
+	}
+
+	out := newDumperT(t, WithFSListing()).DumpStr(fsys)
+	assert.Contains(t, out, "#map[string]*fstest.MapFile {")
+	assert.Contains(t, out, "index.html => 13 bytes")
+	assert.Contains(t, out, "static => (dir)")
+	assert.Contains(t, out, "static/app.js => 14 bytes")
+	assert.Contains(t, out, "static/app.css => 0 bytes")
+
+	out = newDumperT(t, WithFSListing(), WithMaxItems(1)).DumpStr(fsys)
+	assert.Contains(t, out, "index.html")
+	assert.Contains(t, out, "... (truncated)")
+	assert.NotContains(t, out, "app.js")
+
+	out = newDumperT(t, WithFSListing()).DumpStr(brokenFS{})
+	assert.Contains(t, out, "<error: boom>")
+
+	assert.NotContains(t, dumpStrT(t, fsys), "bytes")
+}
+
+type fakeMessageState struct{ _ [0]func() }
+
+type fakeProtoMessage struct {
+	state         fakeMessageState
+	sizeCache     int32
+	unknownFields []byte
+
+	Name                 string
+	Tags                 []string
+	XXX_unrecognized     []byte
+	XXX_NoUnkeyedLiteral struct{}
+}
+
+func (m *fakeProtoMessage) Reset()            { *m = fakeProtoMessage{} }
+func (m *fakeProtoMessage) String() string    { return "name:" + m.Name }
+func (m *fakeProtoMessage) ProtoReflect() any { return nil }
+
+func TestHideProtoInternals(t *testing.T) {
+	msg := &fakeProtoMessage{Name: "Alice", Tags: []string{"admin"}, sizeCache: 7}
+
+	out := newDumperT(t, WithHideProtoInternals(), WithDisableStringer(true)).DumpStr(msg)
+	assert.Contains(t, out, `+Name => "Alice" #string`)
+	assert.Contains(t, out, `"admin"`)
+	for _, noise := range []string{"state", "sizeCache", "unknownFields", "XXX_unrecognized", "XXX_NoUnkeyedLiteral"} {
+		assert.NotContains(t, out, noise)
+	}
+
+	out = newDumperT(t, WithDisableStringer(true)).DumpStr(msg)
+	assert.Contains(t, out, "-sizeCache")
+
+	type plain struct {
+		state int
+	}
+	out = newDumperT(t, WithHideProtoInternals()).DumpStr(plain{state: 1})
+	assert.Contains(t, out, "-state")
+}
+
+func TestContainerSequences(t *testing.T) {
+	l := list.New()
+	l.PushBack(1)
+	l.PushBack("two")
+	l.PushBack(3.5)
+
+	d := newDumperT(t, WithContainerSequences())
+	out := d.DumpStr(l)
+	assert.Contains(t, out, "#*list.List [\n  0 => 1 #int\n  1 => \"two\" #string\n  2 => 3.500000 #float64\n]")
+
+	type Holder struct {
+		Queue list.List
+	}
+	h := Holder{}
+	h.Queue.PushBack("a")
+	out = d.DumpStr(&h)
+	assert.Contains(t, out, `+Queue => #list.List [`)
+	assert.Contains(t, out, `0 => "a" #string`)
+
+	r := ring.New(4)
+	for i := 0; i < r.Len(); i++ {
+		r.Value = i * 10
+		r = r.Next()
+	}
+	out = d.DumpStr(r)
+	assert.Contains(t, out, "#*ring.Ring [\n  0 => 0 #int\n  1 => 10 #int\n  2 => 20 #int\n  3 => 30 #int\n]")
+	assert.NotContains(t, out, "↩︎")
+
+	out = newDumperT(t, WithContainerSequences(), WithMaxItems(2)).DumpStr(r)
+	assert.Contains(t, out, "1 => 10 #int")
+	assert.NotContains(t, out, "2 => 20 #int")
+	assert.Contains(t, out, "... (truncated)")
+
+	assert.Contains(t, dumpStrT(t, l), "-root")
+}
+
+func TestTimerPlaceholders(t *testing.T) {
+	type Scheduler struct {
+		Timer  *time.Timer
+		Ticker *time.Ticker
+	}
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	out := dumpStrT(t, Scheduler{Timer: timer, Ticker: ticker})
+	assert.Contains(t, out, "+Timer  => time.Timer{…} #*time.Timer")
+	assert.Contains(t, out, "+Ticker => time.Ticker{…} #*time.Ticker")
+	assert.NotContains(t, out, "runtimeTimer")
+	assert.NotContains(t, out, "+C ")
+
+	raw := newDumperT(t, WithRawMode()).DumpStr(timer)
+	assert.NotContains(t, raw, "time.Timer{…}")
+}
+
+func TestFileFormatting(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "dump-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := dumpStrT(t, f)
+	assert.True(t, regexp.MustCompile(`^os\.File\(name=\S+dump-\d+\.txt fd=\d+\) #\*os\.File\n$`).MatchString(out), out)
+	assert.Contains(t, out, f.Name())
+	assert.NotContains(t, out, "pfd")
+	assert.NotContains(t, out, "Sysfd")
+
+	f.Close()
+	assert.Equal(t, "os.File(name="+f.Name()+" closed) #*os.File\n", dumpStrT(t, f))
+
+	var nilFile *os.File
+	assert.Equal(t, "*os.File(nil)\n", dumpStrT(t, nilFile))
+	assert.Equal(t, "os.File() #*os.File\n", dumpStrT(t, &os.File{}))
+}
+
+func TestSkipStdlibInternals(t *testing.T) {
+	type Service struct {
+		mu       sync.Mutex
+		rw       sync.RWMutex
+		wg       sync.WaitGroup
+		once     sync.Once
+		Endpoint url.URL
+		Value    reflect.Value
+		Created  time.Time
+		Queue    *list.List
+	}
+	q := list.New()
+	q.PushBack("job")
+	svc := &Service{
+		Endpoint: url.URL{Scheme: "https", Host: "example.com", Path: "/v1"},
+		Value:    reflect.ValueOf(42),
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Queue:    q,
+	}
+
+	out := newDumperT(t, WithSkipStdlibInternals()).DumpStr(svc)
+	assert.Contains(t, out, "sync.Mutex{…} #sync.Mutex")
+	assert.Contains(t, out, "sync.RWMutex{…} #sync.RWMutex")
+	assert.Contains(t, out, "sync.WaitGroup{…} #sync.WaitGroup")
+	assert.Contains(t, out, "sync.Once{…} #sync.Once")
+	assert.Contains(t, out, "https://example.com/v1 #url.URL")
+	assert.Contains(t, out, "reflect.Value(42 int) #reflect.Value")
+	assert.Contains(t, out, "2024-01-02 03:04:05 +0000 UTC #time.Time")
+	assert.Contains(t, out, `0 => "job" #string`)
+	for _, internal := range []string{"-state", "-sema", "-flag", "+RawQuery", "-root"} {
+		assert.NotContains(t, out, internal)
+	}
+
+	plain := dumpStrT(t, svc)
+	assert.Contains(t, plain, "+Scheme")
+	assert.Contains(t, plain, "<int Value> #reflect.Value")
+}
+
+type rangeCache struct {
+	mu    sync.Mutex
+	items map[string]int
+}
+
+func (c *rangeCache) Range(fn func(key, value any) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range c.items {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+func TestRangeMapRendering(t *testing.T) {
+	cache := &rangeCache{items: map[string]int{"b": 2, "a": 1, "ccc": 3}}
+	out := dumpStrT(t, cache)
+	assert.Equal(t, "#*godump.rangeCache {\n   a   => 1 #int\n   b   => 2 #int\n   ccc => 3 #int\n}\n", out)
+
+	var sm sync.Map
+	sm.Store("k", []string{"v"})
+	type Holder struct {
+		Cache sync.Map
+	}
+	h := &Holder{}
+	h.Cache.Store(1, "one")
+	out = dumpStrT(t, h)
+	assert.Contains(t, out, "+Cache => #sync.Map {\n     1 => \"one\" #string\n  }")
+	assert.Contains(t, dumpStrT(t, &sm), " k => #[]string [")
+
+	var kinds []string
+	var totals []int
+	out = newDumperT(t, WithMaxItems(2), WithCallbackOnTruncate(func(kind, _ string, total int) {
+		kinds = append(kinds, kind)
+		totals = append(totals, total)
+	})).DumpStr(cache)
+	assert.Equal(t, "#*godump.rangeCache {\n   a => 1 #int\n   b => 2 #int\n  ... (truncated)\n}\n", out)
+	assert.Equal(t, []string{"map"}, kinds)
+	assert.Equal(t, []int{3}, totals)
+
+	raw := newDumperT(t, WithRawMode()).DumpStr(cache)
+	assert.Contains(t, raw, "-items")
+}
+
+func TestFlagSetRendering(t *testing.T) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.Int("port", 80, "listen port")
+	fs.Bool("verbose", false, "log more")
+	assert.NoError(t, fs.Parse([]string{"-port", "8080"}))
+
+	out := stripANSI(newDumperT(t).DumpStr(fs))
+	assert.Contains(t, out, "#*flag.FlagSet {")
+	assert.Contains(t, out, `port=8080 (default=80, usage="listen port")`)
+	assert.Contains(t, out, `verbose=false (default=false, usage="log more")`)
+	assert.NotContains(t, out, "formal")
+	assert.NotContains(t, out, "actual")
+	assert.True(t, regexp.MustCompile(`(?s)port=.*verbose=`).MatchString(out))
+
+	type CLI struct{ Flags *flag.FlagSet }
+	out = stripANSI(newDumperT(t, WithMaxItems(1)).DumpStr(CLI{Flags: fs}))
+	assert.Contains(t, out, "port=8080")
+	assert.NotContains(t, out, "verbose=")
+	assert.Contains(t, out, "... (truncated)")
+}
+
+func TestTimeFormatInCollections(t *testing.T) {
+	a := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := a.Add(time.Hour)
+	type Event struct {
+		At    time.Time
+		Times []time.Time
+		ByKey map[string]time.Time
+		Ptrs  []*time.Time
+		Fixed [1]time.Time
+	}
+	v := Event{At: a, Times: []time.Time{a, b}, ByKey: map[string]time.Time{"start": a}, Ptrs: []*time.Time{&b}, Fixed: [1]time.Time{a}}
+
+	out := newDumperT(t, WithTimeFormat(time.RFC3339)).DumpStr(v)
+	assert.Equal(t, 4, strings.Count(out, "2024-01-02T03:04:05Z #time.Time"))
+	assert.Contains(t, out, "2024-01-02T04:04:05Z #time.Time")
+	assert.Contains(t, out, "2024-01-02T04:04:05Z #*time.Time")
+	assert.NotContains(t, out, "+0000 UTC")
+
+	out = newDumperT(t, WithTimeFormat(time.Kitchen)).DumpStr([]time.Time{a, b})
+	assert.Equal(t, "#[]time.Time [\n  0 => 3:04AM #time.Time\n  1 => 4:04AM #time.Time\n]\n", out)
+}
+
+func TestTimeFormatRFC3339AndZero(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.FixedZone("CEST", 2*60*60))
+	d := newDumperT(t, WithTimeFormat(time.RFC3339))
+
+	assert.Equal(t, "2024-05-06T07:08:09+02:00 #time.Time\n", d.DumpStr(at))
+	assert.Equal(t, "2024-05-06T07:08:09+02:00 #*time.Time\n", d.DumpStr(&at))
+
+	var zero time.Time
+	assert.Equal(t, "time.Time(zero) #time.Time\n", d.DumpStr(zero))
+	assert.Equal(t, "time.Time(zero) #*time.Time\n", d.DumpStr(&zero))
+
+	type Job struct {
+		Started  time.Time
+		Finished *time.Time
+	}
+	out := d.DumpStr(Job{Started: at})
+	assert.Contains(t, out, "+Started  => 2024-05-06T07:08:09+02:00 #time.Time")
+	assert.Contains(t, out, "+Finished => *time.Time(nil)")
+
+	// Without the option the zero time keeps its String form.
+	assert.NotContains(t, dumpStrT(t, zero), "time.Time(zero)")
+}
+
+type orderedMap struct {
+	keys   []string
+	values map[string]int
+}
+
+func (m *orderedMap) Set(k string, v int) {
+	if _, ok := m.values[k]; !ok {
+		m.keys = append(m.keys, k)
+	}
+	m.values[k] = v
+}
+
+func (m *orderedMap) Keys() []string { return m.keys }
+
+func (m *orderedMap) Get(k string) (int, bool) {
+	v, ok := m.values[k]
+	return v, ok
+}
+
+func TestOrderedMapRendering(t *testing.T) {
+	m := &orderedMap{values: map[string]int{}}
+	m.Set("zeta", 1)
+	m.Set("alpha", 2)
+	m.Set("mid", 3)
+
+	out := newDumperT(t).DumpStr(m)
+	assert.Equal(t, "#*godump.orderedMap {\n   zeta  => 1 #int\n   alpha => 2 #int\n   mid   => 3 #int\n}\n", out)
+
+	type Holder struct{ Index orderedMap }
+	out = newDumperT(t, WithMaxMapItems(2)).DumpStr(&Holder{Index: *m})
+	assert.Contains(t, out, "zeta  => 1 #int")
+	assert.Contains(t, out, "alpha => 2 #int")
+	assert.NotContains(t, out, "mid")
+	assert.Contains(t, out, "... (truncated)")
+}
@@ -1,46 +1,76 @@
 package godump
 
 import (
+	"bytes"
+	"context"
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"html"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"time"
+	"unicode"
 	"unicode/utf8"
 	"unsafe"
 )
 
 const (
-	colorReset   = "\033[0m"
-	colorGray    = "\033[90m"
-	colorYellow  = "\033[33m"
-	colorRed     = "\033[31m"
-	colorGreen   = "\033[32m"
-	colorRedBg   = "\033[48;2;34;16;16m"
-	colorGreenBg = "\033[48;2;16;34;22m"
-	colorLime    = "\033[1;38;5;113m"
-	colorCyan    = "\033[38;5;38m"
-	colorNote    = "\033[38;5;38m"
-	colorRef     = "\033[38;5;247m"
-	colorMeta    = "\033[38;5;170m"
-	colorDefault = "\033[38;5;208m"
-	indentWidth  = 2
+	colorReset         = "\033[0m"
+	colorGray          = "\033[90m"
+	colorYellow        = "\033[33m"
+	colorRed           = "\033[31m"
+	colorGreen         = "\033[32m"
+	colorRedBg         = "\033[48;2;34;16;16m"
+	colorGreenBg       = "\033[48;2;16;34;22m"
+	colorLime          = "\033[1;38;5;113m"
+	colorCyan          = "\033[38;5;38m"
+	colorNote          = "\033[38;5;38m"
+	colorRef           = "\033[38;5;247m"
+	colorMeta          = "\033[38;5;170m"
+	colorDefault       = "\033[38;5;208m"
+	colorPkg1          = "\033[38;5;75m"
+	colorPkg2          = "\033[38;5;114m"
+	colorPkg3          = "\033[38;5;180m"
+	colorPkg4          = "\033[38;5;176m"
+	colorPkg5          = "\033[38;5;116m"
+	colorPkg6          = "\033[38;5;216m"
+	defaultIndentWidth = 2
 )
 
 // Default configuration values for the Dumper.
 const (
+	defaultEmptyJSON       = `{"error": "DumpJSON called with no arguments"}`
 	defaultDisableStringer = false
 	defaultMaxDepth        = 15
 	defaultMaxItems        = 100
 	defaultMaxStringLen    = 100000
 	defaultMaxStackDepth   = 10
+	defaultHexGroupSize    = 8
 	initialCallerSkip      = 2
 )
 
+// Limits applied by WithSafeLimits.
+const (
+	safeMaxDepth       = 8
+	safeMaxItems       = 50
+	safeMaxStringLen   = 1024
+	safeMaxNodes       = 10000
+	safeMaxOutputBytes = 1 << 20
+	safeTimeout        = time.Second
+)
+
 const (
 	// FieldMatchExact matches field names exactly (case-insensitive).
 	FieldMatchExact FieldMatchMode = iota
@@ -88,6 +118,88 @@ var exitFunc = os.Exit
 // Colorizer is a function type that takes a color code and a string, returning the colorized string.
 type Colorizer func(code, str string) string
 
+// Theme maps the roles in a dump to color codes. Codes are ANSI escape sequences
+// such as "\033[38;5;208m", or 24-bit colors built with RGB; an empty code leaves
+// that role uncolored.
+type Theme struct {
+	StringColor string // string contents and Stringer output
+	NumberColor string // ints, uints, floats, and addresses
+	TypeColor   string // type markers such as #int
+	KeyColor    string // map keys and method, flag, and func names
+	NullColor   string // nil and invalid values
+	RefColor    string // pointer reference ids and cycle markers
+	MetaColor   string // annotations such as truncation notes and field counts
+	SymbolColor string // quotes, field visibility symbols, and true
+	ErrorColor  string // errors and redacted values
+
+	ZeroColor     string   // zero values with WithSemanticColors
+	BoolColor     string   // non-zero bools with WithSemanticColors
+	PackageColors []string // type marker palette for WithPackageColors, picked by package path hash
+
+	DiffAddColor         string // "+" markers of inserted diff lines
+	DiffRemoveColor      string // "-" markers of deleted diff lines
+	DiffAddBackground    string // background of inserted diff lines
+	DiffRemoveBackground string // background of deleted diff lines
+}
+
+// DefaultTheme returns the built-in color palette.
+// @group Options
+//
+// Example: start from the default palette
+//
+//	theme := godump.DefaultTheme()
+//	theme.StringColor = "\033[34m"
+//	d := godump.NewDumper(godump.WithTheme(theme))
+//	_ = d
+func DefaultTheme() Theme {
+	return Theme{
+		StringColor: colorLime,
+		NumberColor: colorCyan,
+		TypeColor:   colorGray,
+		KeyColor:    colorMeta,
+		NullColor:   colorGray,
+		RefColor:    colorRef,
+		MetaColor:   colorGray,
+		SymbolColor: colorYellow,
+		ErrorColor:  colorRed,
+
+		ZeroColor:     colorGray,
+		BoolColor:     colorGreen,
+		PackageColors: []string{colorPkg1, colorPkg2, colorPkg3, colorPkg4, colorPkg5, colorPkg6},
+
+		DiffAddColor:         colorGreen,
+		DiffRemoveColor:      colorRed,
+		DiffAddBackground:    colorGreenBg,
+		DiffRemoveBackground: colorRedBg,
+	}
+}
+
+// MonochromeTheme returns a theme with no colors, for terminals or logs where only
+// the layout matters.
+// @group Options
+//
+// Example: dump without colors
+//
+//	d := godump.NewDumper(godump.WithTheme(godump.MonochromeTheme()))
+//	d.Dump("hello")
+//	// "hello" #string
+func MonochromeTheme() Theme {
+	return Theme{}
+}
+
+// RGB returns the 24-bit ANSI color code for r, g, b, for use in a [Theme].
+// @group Options
+//
+// Example: brand colors
+//
+//	theme := godump.DefaultTheme()
+//	theme.StringColor = godump.RGB(0, 173, 216)
+//	d := godump.NewDumper(godump.WithTheme(theme), godump.WithTrueColor())
+//	_ = d
+func RGB(r, g, b uint8) string {
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
 // colorizeUnstyled returns the string without any colorization.
 //
 // It satisfies the [Colorizer] interface.
@@ -113,31 +225,136 @@ var htmlColorMap = map[string]string{
 	colorRef:     "#aaa",
 	colorMeta:    "#d087d0",
 	colorDefault: "#ff7f00",
+	colorPkg1:    "#5fafff",
+	colorPkg2:    "#87d787",
+	colorPkg3:    "#d7af87",
+	colorPkg4:    "#d787d7",
+	colorPkg5:    "#87d7d7",
+	colorPkg6:    "#ffaf87",
+	colorRedBg:   "#221010",
+	colorGreenBg: "#102216",
 }
 
 // colorizeHTML colorizes the string using HTML span tags.
 //
 // It satisfies the [Colorizer] interface.
 func colorizeHTML(code, str string) string {
-	return fmt.Sprintf(`<span style="color:%s">%s</span>`, htmlColorMap[code], str)
+	color, ok := htmlColorMap[code]
+	if !ok {
+		var r, g, b uint8
+		if _, err := fmt.Sscanf(code, "\033[38;2;%d;%d;%dm", &r, &g, &b); err == nil {
+			color = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+		}
+	}
+	return fmt.Sprintf(`<span style="color:%s">%s</span>`, color, str)
+}
+
+// trueColorMap maps color codes to 24-bit ANSI sequences matching the HTML palette.
+var trueColorMap = buildTrueColorMap(htmlColorMap)
+
+// buildTrueColorMap converts #rgb/#rrggbb colors into \x1b[38;2;R;G;Bm sequences.
+func buildTrueColorMap(hexColors map[string]string) map[string]string {
+	out := make(map[string]string, len(hexColors))
+	for code, hex := range hexColors {
+		hex = strings.TrimPrefix(hex, "#")
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		var r, g, b uint8
+		if _, err := fmt.Sscanf(hex, "%02x%02x%02x", &r, &g, &b); err != nil {
+			continue
+		}
+		out[code] = fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+	}
+	return out
+}
+
+// colorizeTrueColor colorizes the string using 24-bit ANSI escape codes.
+//
+// It satisfies the [Colorizer] interface.
+func colorizeTrueColor(code, str string) string {
+	if tc, ok := trueColorMap[code]; ok {
+		code = tc
+	}
+	return code + str + colorReset
 }
 
 // Dumper holds configuration for dumping structured data.
 // It controls depth, item count, and string length limits.
 type Dumper struct {
-	maxDepth           int
-	maxItems           int
-	maxStringLen       int
-	writer             io.Writer
-	skippedStackFrames int
-	disableStringer    bool
-	disableColor       bool
-	disableHeader      bool
-	includeFields      []string
-	excludeFields      []string
-	redactFields       []string
-	fieldMatchMode     FieldMatchMode
-	redactMatchMode    FieldMatchMode
+	maxDepth            int
+	maxPathDepth        int
+	maxItems            int
+	maxMapItems         int
+	arrayIndexWidth     int
+	indexBase           int
+	sampleItems         int
+	maxStringLen        int
+	maxWidth            int
+	hexBaseOffset       uint64
+	hexGroupSize        int
+	trueString          string
+	falseString         string
+	writer              io.Writer
+	extraWriters        []io.Writer
+	skippedStackFrames  int
+	disableStringer     bool
+	disableColor        bool
+	disableHeader       bool
+	headerFormat        func(file string, line int) string
+	slogLevel           any // slog.Leveler set by WithSlogLevel; untyped so this file builds before Go 1.21
+	theme               Theme
+	headless            bool
+	includeFields       []string
+	excludeFields       []string
+	elideFields         map[string]struct{}
+	typeAliases         map[reflect.Type]string
+	lazyFormatters      map[reflect.Type]func(reflect.Value) string
+	redactFields        []string
+	redactPatterns      []*regexp.Regexp
+	fieldMatchMode      FieldMatchMode
+	redactMatchMode     FieldMatchMode
+	thousandsSep        rune
+	decimalSep          rune
+	htmlDataAttrs       bool
+	matrixView          bool
+	nilFormatter        func(t reflect.Type) string
+	renderFunc          func(v reflect.Value, indent int) (string, bool)
+	onRecursionLimit    func(path string, t reflect.Type) int
+	onTruncate          func(kind, path string, total int)
+	sortMapKeys         bool
+	mapValueDedup       bool
+	fsListing           bool
+	pointerIDs          bool
+	emptyJSON           string
+	markPointers        bool
+	structFieldCount    bool
+	rawMode             bool
+	collapseSingle      bool
+	hideProtoInternals  bool
+	packageColors       bool
+	semanticColors      bool
+	trueColor           bool
+	cycleFullPath       bool
+	drainChannels       bool
+	onlyNonDefault      bool
+	showMethods         bool
+	depthNumbers        bool
+	quoteMapKeys        bool
+	maxTypeParamsLen    int
+	zeroPointersAsNil   bool
+	interfaceMethodSets bool
+	jsonMarshalers      bool
+	obfuscateValues     bool
+	timeFormat          string
+	maxNodes            int
+	maxOutputBytes      int
+	timeout             time.Duration
+	autoFlush           bool
+	indentWidth         int
+	containerSequences  bool
+	skipStdlibInternals bool
+	htmlOutput          bool
 
 	// callerFn is used to get the caller information.
 	// It defaults to [runtime.Caller], it is here to be overridden for testing purposes.
@@ -150,17 +367,100 @@ type Dumper struct {
 // Option defines a functional option for configuring a Dumper.
 type Option func(*Dumper) *Dumper
 
-// dumpState tracks reference ids for a single dump call.
+// dumpState tracks reference ids and the current value path for a single dump call.
 type dumpState struct {
 	nextRefID int
-	refs      map[uintptr]int
+	refs      map[refKey]int
+	path      []pathSegment
+
+	// fieldDepth counts the struct field segments in path.
+	fieldDepth int
+
+	// extraDepth is the depth granted beyond maxDepth by WithRecursionLimitCallback
+	// for the subtree being printed.
+	extraDepth int
+
+	// refPaths holds the path where each reference id was first seen, for WithCycleFullPath.
+	refPaths map[int]string
+
+	// nodes counts the values visited, for the WithSafeLimits node cap.
+	nodes int
+	// out and ctx enforce the WithSafeLimits output cap and timeout; both are nil otherwise.
+	out *limitedWriter
+	ctx context.Context
+}
+
+// refKey identifies a pointed-to object by address and pointer type, so that a struct and
+// its first field, which share an address, are not mistaken for the same object.
+type refKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// trackableRef returns the reference key for pointer v and reports whether it can be tracked.
+// Pointers to zero-size types are not tracked: distinct zero-size objects may share an address.
+func trackableRef(v reflect.Value) (refKey, bool) {
+	if v.Type().Elem().Size() == 0 {
+		return refKey{}, false
+	}
+	return refKey{ptr: pointerOf(v), typ: v.Type()}, true
+}
+
+// pathSegment is one step from a parent value to a child: a struct field, a map key, or an index.
+type pathSegment struct {
+	name    string
+	index   int
+	isIndex bool
+	isKey   bool
+}
+
+// pushField descends into a struct field.
+func (s *dumpState) pushField(name string) {
+	s.path = append(s.path, pathSegment{name: name})
+	s.fieldDepth++
+}
+
+// pushKey descends into a map entry.
+func (s *dumpState) pushKey(key string) {
+	s.path = append(s.path, pathSegment{name: key, isKey: true})
+}
+
+// pushIndex descends into a slice or array element.
+func (s *dumpState) pushIndex(i int) {
+	s.path = append(s.path, pathSegment{index: i, isIndex: true})
+}
+
+// popPath returns to the parent value.
+func (s *dumpState) popPath() {
+	last := s.path[len(s.path)-1]
+	if !last.isIndex && !last.isKey {
+		s.fieldDepth--
+	}
+	s.path = s.path[:len(s.path)-1]
+}
+
+// currentPath renders the path of the value being printed, rooted at "$".
+func (s *dumpState) currentPath() string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, seg := range s.path {
+		switch {
+		case seg.isIndex:
+			fmt.Fprintf(&sb, "[%d]", seg.index)
+		case seg.isKey:
+			sb.WriteString("[" + seg.name + "]")
+		default:
+			sb.WriteString("." + seg.name)
+		}
+	}
+	return sb.String()
 }
 
 // newDumpState initializes per-dump reference tracking.
 func newDumpState() *dumpState {
 	return &dumpState{
 		nextRefID: 1,
-		refs:      map[uintptr]int{},
+		refs:      map[refKey]int{},
 	}
 }
 
@@ -188,6 +488,182 @@ func WithMaxDepth(n int) Option {
 	}
 }
 
+// WithSafeLimits applies conservative caps for dumping untrusted input, such as
+// deserialized payloads, so a deeply nested or huge value cannot stall the dumper.
+// It limits depth to 8, collections to 50 items, strings to 1024 runes, the walk
+// to 10000 values, and the output to 1 MiB, and stops the walk after one second.
+// Maps larger than the node cap are sampled instead of read in full, and the caps
+// also bound DumpSlice and DumpPath.
+// Options given after it override the individual depth, item, and string limits.
+// @group Options
+//
+// Example: dump an untrusted payload
+//
+//	// Default: unlimited nodes, output, and time
+//	var payload any
+//	_ = json.Unmarshal([]byte(`{"a":[1,2,3]}`), &payload)
+//	d := godump.NewDumper(godump.WithSafeLimits())
+//	d.Dump(payload)
+//	// #map[string]interface {} {
+//	//   a => #[]interface {} [
+//	//     0 => 1 #float64
+//	//     1 => 2 #float64
+//	//     2 => 3 #float64
+//	//   ]
+//	// }
+func WithSafeLimits() Option {
+	return func(d *Dumper) *Dumper {
+		d.maxDepth = safeMaxDepth
+		d.maxItems = safeMaxItems
+		d.maxStringLen = safeMaxStringLen
+		d.maxNodes = safeMaxNodes
+		d.maxOutputBytes = safeMaxOutputBytes
+		d.timeout = safeTimeout
+		return d
+	}
+}
+
+// WithMaxPathDepth limits how many struct field hops are followed from the root value.
+// Unlike WithMaxDepth, slice, array, and map nesting does not count toward the limit.
+// Param n must be greater than 0 to take effect; the default 0 means unlimited.
+// @group Options
+//
+// Example: limit field nesting
+//
+//	// Default: 0 (unlimited)
+//	type Inner struct {
+//		Value int
+//	}
+//	type Outer struct {
+//		Inner Inner
+//	}
+//	d := godump.NewDumper(godump.WithMaxPathDepth(1))
+//	d.Dump(Outer{Inner: Inner{Value: 1}})
+//	// #godump.Outer {
+//	//   +Inner => ... (max path depth)
+//	// }
+func WithMaxPathDepth(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n >= 0 {
+			d.maxPathDepth = n
+		}
+		return d
+	}
+}
+
+// WithArrayIndexWidth right-justifies slice and array indices to a fixed width.
+// Param n must be greater than 0 or this will be ignored, and the width is derived from
+// the highest printed index so that `  0 =>` lines up with `100 =>`.
+// @group Options
+//
+// Example: pad indices
+//
+//	// Default: automatic
+//	v := []int{1, 2, 3}
+//	d := godump.NewDumper(godump.WithArrayIndexWidth(3))
+//	d.Dump(v)
+//	// #[]int [
+//	//     0 => 1 #int
+//	//     1 => 2 #int
+//	//     2 => 3 #int
+//	// ]
+func WithArrayIndexWidth(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n > 0 {
+			d.arrayIndexWidth = n
+		}
+		return d
+	}
+}
+
+// WithOneBasedIndices numbers slice and array elements starting at 1 instead of 0.
+// This only changes the displayed labels; paths and selectors stay zero-based.
+// @group Options
+//
+// Example: one-based indices
+//
+//	// Default: false
+//	v := []string{"a", "b"}
+//	d := godump.NewDumper(godump.WithOneBasedIndices())
+//	d.Dump(v)
+//	// #[]string [
+//	//   1 => "a" #string
+//	//   2 => "b" #string
+//	// ]
+func WithOneBasedIndices() Option {
+	return func(d *Dumper) *Dumper {
+		d.indexBase = 1
+		return d
+	}
+}
+
+// WithMaxWidth wraps output lines that would exceed cols visible columns, continuing them
+// on the next line one indent level deeper. ANSI color codes don't count toward the width.
+// Param cols must be greater than 0 or this will be ignored; HTML output is never wrapped.
+// @group Options
+//
+// Example: wrap at 40 columns
+//
+//	// Default: 0 (no wrapping)
+//	v := map[string]string{"query": strings.Repeat("x", 60)}
+//	d := godump.NewDumper(godump.WithMaxWidth(40))
+//	d.Dump(v)
+//	// #map[string]string {
+//	//    query => "xxxxxxxxxxxxxxxxxxxxxxxxxxx
+//	//      xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+//	//      #string
+//	// }
+func WithMaxWidth(cols int) Option {
+	return func(d *Dumper) *Dumper {
+		if cols > 0 {
+			d.maxWidth = cols
+		}
+		return d
+	}
+}
+
+// WithHexDumpBaseOffset starts the offset column of byte slice hex dumps at base instead
+// of 0, e.g. to show real file or packet offsets. Only the displayed offsets change.
+// @group Options
+//
+// Example: show file offsets
+//
+//	// Default: 0
+//	chunk := []byte("hello")
+//	d := godump.NewDumper(godump.WithHexDumpBaseOffset(0x1000))
+//	d.Dump(chunk)
+//	// ([]uint8) (len=5 cap=5) {
+//	//   00001000  68 65 6c 6c 6f                                    | hello            |
+//	// }
+func WithHexDumpBaseOffset(base uint64) Option {
+	return func(d *Dumper) *Dumper {
+		d.hexBaseOffset = base
+		return d
+	}
+}
+
+// WithHexDumpGroupSize sets how many bytes of a hex dump line are grouped before an
+// extra space, e.g. 2, 4, or 8. Param n of 0 disables grouping; negative values are ignored.
+// @group Options
+//
+// Example: group by 4 bytes
+//
+//	// Default: 8
+//	chunk := []byte("hello, world")
+//	d := godump.NewDumper(godump.WithHexDumpGroupSize(4))
+//	d.Dump(chunk)
+//	// ([]uint8) (len=12 cap=12) {
+//	//   00000000  68 65 6c 6c  6f 2c 20 77  6f 72 6c 64               | hello, world     |
+//	// }
+func WithHexDumpGroupSize(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n >= 0 {
+			d.hexGroupSize = n
+		}
+		return d
+	}
+}
+
 // WithMaxItems limits how many items from an array, slice, or map can be printed.
 // Param n must be 0 or greater or this will be ignored, and default MaxItems will be 100.
 // @group Options
@@ -201,7 +677,7 @@ func WithMaxDepth(n int) Option {
 //	// #[]int [
 //	//   0 => 1 #int
 //	//   1 => 2 #int
-//	//   ... (truncated)
+//	//   ... (1 more truncated)
 //	// ]
 func WithMaxItems(n int) Option {
 	return func(d *Dumper) *Dumper {
@@ -212,6 +688,104 @@ func WithMaxItems(n int) Option {
 	}
 }
 
+// WithSortedMapKeys controls whether map entries are printed in sorted key order, so the
+// same map always dumps the same way. Numeric and string keys sort by value, and other
+// keys by their rendered form. Pass false to keep Go's randomized iteration order.
+// @group Options
+//
+// Example: keep raw map order
+//
+//	// Default: true
+//	v := map[string]int{"b": 2, "a": 1}
+//	d := godump.NewDumper(godump.WithSortedMapKeys(false))
+//	d.Dump(v)
+//	// #map[string]int {
+//	//   b => 2 #int
+//	//   a => 1 #int
+//	// }
+func WithSortedMapKeys(sorted bool) Option {
+	return func(d *Dumper) *Dumper {
+		d.sortMapKeys = sorted
+		return d
+	}
+}
+
+// WithIndentWidth sets how many spaces each nesting level is indented by, in dumps and
+// in JSON output. Param n must be 0 or greater or this will be ignored.
+// @group Options
+//
+// Example: indent with four spaces
+//
+//	// Default: 2
+//	v := map[string][]int{"a": {1}}
+//	d := godump.NewDumper(godump.WithIndentWidth(4))
+//	d.Dump(v)
+//	// #map[string][]int {
+//	//      a => #[]int [
+//	//         0 => 1 #int
+//	//     ]
+//	// }
+func WithIndentWidth(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n >= 0 {
+			d.indentWidth = n
+		}
+		return d
+	}
+}
+
+// WithMaxMapItems limits how many entries of each map are printed, separately from
+// WithMaxItems, which then only bounds slices and arrays. Every map gets its own budget,
+// so sibling and nested maps truncate independently.
+// Param n must be greater than 0 or this will be ignored, and maps will follow WithMaxItems.
+// @group Options
+//
+// Example: show fewer map entries than slice items
+//
+//	// Default: same as WithMaxItems
+//	v := map[string]int{"a": 1, "b": 2, "c": 3}
+//	d := godump.NewDumper(godump.WithMaxMapItems(2))
+//	d.Dump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	//   b => 2 #int
+//	//   ... (1 more truncated)
+//	// }
+func WithMaxMapItems(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n > 0 {
+			d.maxMapItems = n
+		}
+		return d
+	}
+}
+
+// WithSampleLargeCollections shows the first and last elements of long slices and arrays.
+// Slices and arrays longer than n print n/2 leading and n/2 trailing elements around an omitted marker,
+// replacing the MaxItems cut-off. Maps have no order, so they show their first n entries.
+// Param n must be greater than 0 to take effect.
+// @group Options
+//
+// Example: sample a long slice
+//
+//	// Default: 0 (disabled)
+//	v := []int{1, 2, 3, 4, 5, 6}
+//	d := godump.NewDumper(godump.WithSampleLargeCollections(2))
+//	d.Dump(v)
+//	// #[]int [
+//	//   0 => 1 #int
+//	//   ... (4 omitted)
+//	//   5 => 6 #int
+//	// ]
+func WithSampleLargeCollections(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n >= 0 {
+			d.sampleItems = n
+		}
+		return d
+	}
+}
+
 // WithMaxStringLen limits how long printed strings can be.
 // Param n must be 0 or greater or this will be ignored, and default MaxStringLen will be 100000.
 // @group Options
@@ -252,6 +826,45 @@ func WithWriter(w io.Writer) Option {
 	}
 }
 
+// WithAutoFlush flushes the writer after every Dump, DumpSlice, DumpJSON, and Diff when it
+// has a Flush() error method, like *bufio.Writer, so output isn't lost if the program exits.
+// Extra writers are flushed too.
+// @group Options
+//
+// Example: dump through a buffered writer
+//
+//	// Default: false
+//	w := bufio.NewWriter(os.Stdout)
+//	d := godump.NewDumper(godump.WithWriter(w), godump.WithAutoFlush())
+//	d.Dump(1)
+//	// 1 #int
+func WithAutoFlush() Option {
+	return func(d *Dumper) *Dumper {
+		d.autoFlush = true
+		return d
+	}
+}
+
+// WithExtraWriter sends an uncolored copy of every Dump, DumpSlice, and Diff to w.
+// The primary writer keeps colorized output, so a terminal and a log file can both be fed.
+// @group Options
+//
+// Example: tee to a log file
+//
+//	// Default: none
+//	var log strings.Builder
+//	d := godump.NewDumper(godump.WithExtraWriter(&log))
+//	d.Dump(map[string]int{"a": 1})
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func WithExtraWriter(w io.Writer) Option {
+	return func(d *Dumper) *Dumper {
+		d.extraWriters = append(d.extraWriters, w)
+		return d
+	}
+}
+
 // WithSkipStackFrames skips additional stack frames for header reporting.
 // This is useful when godump is wrapped and the actual call site is deeper.
 // @group Options
@@ -314,6 +927,26 @@ func WithoutColor() Option {
 	}
 }
 
+// WithTrueColor renders colors with 24-bit ANSI sequences for terminals that support them.
+// It has no effect when colors are disabled, including through NO_COLOR.
+// @group Options
+//
+// Example: enable truecolor output
+//
+//	// Default: false (256-color palette, unless COLORTERM=truecolor)
+//	v := map[string]int{"a": 1}
+//	d := godump.NewDumper(godump.WithTrueColor())
+//	d.Dump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func WithTrueColor() Option {
+	return func(d *Dumper) *Dumper {
+		d.trueColor = true
+		return d
+	}
+}
+
 // WithoutHeader disables printing the source location header.
 // @group Options
 //
@@ -330,756 +963,3195 @@ func WithoutHeader() Option {
 	}
 }
 
-// WithOnlyFields limits struct output to fields that match the provided names.
+// WithTheme sets the colors used for each role in a dump, such as strings, numbers,
+// and type markers. Start from DefaultTheme to change only a few roles.
 // @group Options
 //
-// Example: include-only fields
+// Example: custom string color
 //
-//	// Default: none
-//	type User struct {
-//		ID       int
-//		Email    string
-//		Password string
-//	}
-//	d := godump.NewDumper(
-//		godump.WithOnlyFields("ID", "Email"),
-//	)
-//	d.Dump(User{ID: 1, Email: "user@example.com", Password: "secret"})
-//	// #godump.User {
-//	//   +ID    => 1 #int
-//	//   +Email => "user@example.com" #string
-//	// }
-func WithOnlyFields(names ...string) Option {
+//	// Default: DefaultTheme()
+//	theme := godump.DefaultTheme()
+//	theme.StringColor = "\033[34m"
+//	d := godump.NewDumper(godump.WithTheme(theme))
+//	_ = d
+func WithTheme(theme Theme) Option {
 	return func(d *Dumper) *Dumper {
-		d.includeFields = append(d.includeFields, names...)
+		d.theme = theme
 		return d
 	}
 }
 
-// WithExcludeFields omits struct fields that match the provided names.
+// WithHeaderFormat customizes the source location header written under each record of a
+// NewSlogHandler handler. fn receives the logging call's file, relative to the working
+// directory when possible, and line number, and returns the header text. A nil fn
+// restores the default "<#dump // file:line" header. Dump, DumpStr, and the other dump
+// functions print no header, so the option has no effect on their output.
 // @group Options
 //
-// Example: exclude fields
+// Example: custom slog header
 //
-//	// Default: none
-//	type User struct {
-//		ID       int
+//	// Default: nil
+//	logger := slog.New(godump.NewSlogHandler(godump.WithHeaderFormat(func(file string, line int) string {
+//		return fmt.Sprintf("--- %s:%d ---", file, line)
+//	})))
+//	logger.Info("ready")
+//	// 2026-01-02T15:04:05Z INFO ready
+//	// --- main.go:12 ---
+func WithHeaderFormat(fn func(file string, line int) string) Option {
+	return func(d *Dumper) *Dumper {
+		d.headerFormat = fn
+		return d
+	}
+}
+
+// WithHeadless drops the outer type marker and braces when dumping a single struct,
+// map, slice, or array, leaving just its contents. This is separate from the source
+// location header; see WithoutHeader.
+// @group Options
+//
+// Example: dump only the fields of a struct
+//
+//	// Default: false
+//	type User struct {
+//		Name string
+//		Age  int
+//	}
+//	d := godump.NewDumper(godump.WithHeadless())
+//	d.Dump(User{Name: "Ada", Age: 36})
+//	// +Name => "Ada" #string
+//	// +Age  => 36 #int
+func WithHeadless() Option {
+	return func(d *Dumper) *Dumper {
+		d.headless = true
+		return d
+	}
+}
+
+// WithOnlyFields limits struct output to fields that match the provided names.
+// @group Options
+//
+// Example: include-only fields
+//
+//	// Default: none
+//	type User struct {
+//		ID       int
 //		Email    string
 //		Password string
 //	}
 //	d := godump.NewDumper(
-//		godump.WithExcludeFields("Password"),
+//		godump.WithOnlyFields("ID", "Email"),
 //	)
 //	d.Dump(User{ID: 1, Email: "user@example.com", Password: "secret"})
 //	// #godump.User {
 //	//   +ID    => 1 #int
 //	//   +Email => "user@example.com" #string
 //	// }
-func WithExcludeFields(names ...string) Option {
+func WithOnlyFields(names ...string) Option {
 	return func(d *Dumper) *Dumper {
-		d.excludeFields = append(d.excludeFields, names...)
+		d.includeFields = append(d.includeFields, names...)
 		return d
 	}
 }
 
-// WithFieldMatchMode sets how field names are matched for WithExcludeFields.
+// WithExcludeFields omits struct fields that match the provided names.
 // @group Options
 //
-// Example: use substring matching
+// Example: exclude fields
 //
-//	// Default: FieldMatchExact
+//	// Default: none
 //	type User struct {
-//		UserID int
+//		ID       int
+//		Email    string
+//		Password string
 //	}
 //	d := godump.NewDumper(
-//		godump.WithExcludeFields("id"),
-//		godump.WithFieldMatchMode(godump.FieldMatchContains),
+//		godump.WithExcludeFields("Password"),
 //	)
-//	d.Dump(User{UserID: 10})
+//	d.Dump(User{ID: 1, Email: "user@example.com", Password: "secret"})
 //	// #godump.User {
+//	//   +ID    => 1 #int
+//	//   +Email => "user@example.com" #string
 //	// }
-func WithFieldMatchMode(mode FieldMatchMode) Option {
+func WithExcludeFields(names ...string) Option {
 	return func(d *Dumper) *Dumper {
-		d.fieldMatchMode = mode
+		d.excludeFields = append(d.excludeFields, names...)
 		return d
 	}
 }
 
-// WithRedactFields replaces matching struct fields with a redacted placeholder.
+// WithElideFields hides struct fields with exactly these names (case-sensitive) at any depth.
+// It is a stricter shorthand for WithExcludeFields that ignores WithFieldMatchMode.
 // @group Options
 //
-// Example: redact fields
+// Example: elide fields
 //
 //	// Default: none
 //	type User struct {
 //		ID       int
 //		Password string
+//		Token    string
 //	}
 //	d := godump.NewDumper(
-//		godump.WithRedactFields("Password"),
+//		godump.WithElideFields("Password", "Token"),
 //	)
-//	d.Dump(User{ID: 1, Password: "secret"})
+//	d.Dump(User{ID: 1, Password: "secret", Token: "abc"})
 //	// #godump.User {
-//	//   +ID       => 1 #int
-//	//   +Password => <redacted> #string
+//	//   +ID => 1 #int
 //	// }
-func WithRedactFields(names ...string) Option {
+func WithElideFields(names ...string) Option {
 	return func(d *Dumper) *Dumper {
-		d.redactFields = append(d.redactFields, names...)
+		if d.elideFields == nil {
+			d.elideFields = make(map[string]struct{}, len(names))
+		}
+		for _, name := range names {
+			d.elideFields[name] = struct{}{}
+		}
 		return d
 	}
 }
 
-// WithRedactSensitive enables default redaction for common sensitive fields.
+// WithTypeAlias displays alias instead of the full name of t in type markers.
+// Composite types built from t, such as slices or pointers, use the alias for their element.
 // @group Options
 //
-// Example: redact common sensitive fields
+// Example: shorten a verbose type name
 //
-//	// Default: disabled
-//	type User struct {
-//		Password string
-//		Token    string
+//	// Default: none
+//	type VeryLongGeneratedResponseEnvelope struct {
+//		ID int
 //	}
 //	d := godump.NewDumper(
-//		godump.WithRedactSensitive(),
+//		godump.WithTypeAlias(reflect.TypeOf(VeryLongGeneratedResponseEnvelope{}), "Envelope"),
 //	)
-//	d.Dump(User{Password: "secret", Token: "abc"})
-//	// #godump.User {
-//	//   +Password => <redacted> #string
-//	//   +Token    => <redacted> #string
+//	d.Dump(VeryLongGeneratedResponseEnvelope{ID: 1})
+//	// #Envelope {
+//	//   +ID => 1 #int
 //	// }
-func WithRedactSensitive() Option {
+func WithTypeAlias(t reflect.Type, alias string) Option {
 	return func(d *Dumper) *Dumper {
-		d.redactFields = append(d.redactFields, defaultRedactedFields...)
-		d.redactMatchMode = FieldMatchContains
+		if t == nil {
+			return d
+		}
+		aliases := make(map[reflect.Type]string, len(d.typeAliases)+1)
+		for k, v := range d.typeAliases {
+			aliases[k] = v
+		}
+		aliases[t] = alias
+		d.typeAliases = aliases
 		return d
 	}
 }
 
-// WithRedactMatchMode sets how field names are matched for WithRedactFields.
+// WithCallbackOnTruncate registers fn to be called whenever output is truncated.
+// The kind is "string", "slice", "map", or "depth"; path is the location of the
+// truncated value (rooted at "$"); total is the full length, or the nesting depth for "depth".
 // @group Options
 //
-// Example: use substring matching
+// Example: count lossy dumps
 //
-//	// Default: FieldMatchExact
-//	type User struct {
-//		APIKey string
-//	}
+//	// Default: nil
+//	truncations := 0
 //	d := godump.NewDumper(
-//		godump.WithRedactFields("key"),
-//		godump.WithRedactMatchMode(godump.FieldMatchContains),
+//		godump.WithMaxItems(2),
+//		godump.WithCallbackOnTruncate(func(kind, path string, total int) {
+//			truncations++
+//		}),
 //	)
-//	d.Dump(User{APIKey: "abc"})
-//	// #godump.User {
-//	//   +APIKey => <redacted> #string
-//	// }
-func WithRedactMatchMode(mode FieldMatchMode) Option {
+//	d.Dump([]int{1, 2, 3})
+//	// truncations == 1
+func WithCallbackOnTruncate(fn func(kind, path string, total int)) Option {
 	return func(d *Dumper) *Dumper {
-		d.redactMatchMode = mode
+		d.onTruncate = fn
 		return d
 	}
 }
 
-// NewDumper creates a new Dumper with the given options applied.
-// Defaults are used for any setting not overridden.
-// @group Builder
+// WithRecursionLimitCallback registers fn to be called when a value is about to be cut off
+// by WithMaxDepth. The path (rooted at "$") and type of that value are passed; returning
+// n > 0 allows n more levels below it, while 0 truncates as usual.
+// @group Options
 //
-// Example: build a custom dumper
+// Example: dig deeper only into one type
 //
-//	v := map[string]int{"a": 1}
+//	// Default: nil
+//	type Node struct {
+//		Next *Node
+//	}
+//	nodeType := reflect.TypeOf(&Node{})
 //	d := godump.NewDumper(
-//		godump.WithMaxDepth(10),
-//		godump.WithWriter(os.Stdout),
+//		godump.WithMaxDepth(1),
+//		godump.WithRecursionLimitCallback(func(path string, t reflect.Type) int {
+//			if t == nodeType {
+//				return 1
+//			}
+//			return 0
+//		}),
 //	)
-//	d.Dump(v)
-//	// #map[string]int {
-//	//   a => 1 #int
+//	d.Dump(&Node{Next: &Node{}})
+//	// #*godump.Node {
+//	//   +Next => #*godump.Node {
+//	//     +Next => *godump.Node(nil)
+//	//   }
 //	// }
-func NewDumper(opts ...Option) *Dumper {
-	d := &Dumper{
-		maxDepth:        defaultMaxDepth,
-		maxItems:        defaultMaxItems,
-		maxStringLen:    defaultMaxStringLen,
-		disableStringer: defaultDisableStringer,
-		writer:          os.Stdout,
-		colorizer:       nil, // ensure no detection is made if we don't need it
-		callerFn:        runtime.Caller,
-		fieldMatchMode:  FieldMatchExact,
-		redactMatchMode: FieldMatchExact,
-	}
-	for _, opt := range opts {
-		d = opt(d)
+func WithRecursionLimitCallback(fn func(path string, t reflect.Type) int) Option {
+	return func(d *Dumper) *Dumper {
+		d.onRecursionLimit = fn
+		return d
 	}
-	return d
 }
 
-// Dump prints the values to stdout with colorized output.
-// @group Dump
+// WithSemanticColors colors values by meaning in addition to type: errors render their
+// message in red, true renders green, and zero values such as 0, "", and false are dimmed.
+// The colors come from the theme's ErrorColor, BoolColor, and ZeroColor.
+// @group Options
 //
-// Example: print to stdout
+// Example: highlight errors and zero values
 //
-//	v := map[string]int{"a": 1}
-//	godump.Dump(v)
-//	// #map[string]int {
-//	//   a => 1 #int
+//	// Default: false
+//	type Result struct {
+//		OK  bool
+//		Err error
+//	}
+//	d := godump.NewDumper(godump.WithSemanticColors())
+//	d.Dump(Result{Err: fmt.Errorf("timeout")})
+//	// #godump.Result {
+//	//   +OK  => false #bool
+//	//   +Err => timeout #*errors.errorString
 //	// }
-func Dump(vs ...any) {
-	defaultDumper.Dump(vs...)
+func WithSemanticColors() Option {
+	return func(d *Dumper) *Dumper {
+		d.semanticColors = true
+		return d
+	}
 }
 
-// Dump prints the values to stdout with colorized output.
-// @group Dump
+// WithOnlyNonDefault renders only struct fields whose values differ from their zero value.
+// Fields are compared recursively, so empty slices and maps, pointers to zero values, and
+// nested structs with only zero fields are hidden as well. Useful for seeing what a config overrides.
+// @group Options
 //
-// Example: print with a custom dumper
+// Example: show overridden config fields
 //
-//	d := godump.NewDumper()
-//	v := map[string]int{"a": 1}
-//	d.Dump(v)
-//	// #map[string]int {
-//	//   a => 1 #int
+//	// Default: false
+//	type Config struct {
+//		Host    string
+//		Port    int
+//		Debug   bool
+//		Plugins []string
+//	}
+//	d := godump.NewDumper(godump.WithOnlyNonDefault())
+//	d.Dump(Config{Port: 8080, Plugins: []string{}})
+//	// #godump.Config {
+//	//   +Port => 8080 #int
 //	// }
-func (d *Dumper) Dump(vs ...any) {
-	fmt.Fprint(d.writer, d.DumpStr(vs...))
+func WithOnlyNonDefault() Option {
+	return func(d *Dumper) *Dumper {
+		d.onlyNonDefault = true
+		return d
+	}
 }
 
-// Fdump writes the formatted dump of values to the given io.Writer.
-// @group Dump
+// WithLazyFormatter renders values of type t as the summary returned by fn.
+// fn is only called for values that are actually rendered, so expensive summaries are
+// skipped for anything cut off by depth or item limits.
+// @group Options
 //
-// Example: dump to writer
+// Example: summarize an expensive type
 //
-//	var b strings.Builder
-//	v := map[string]int{"a": 1}
-//	godump.Fdump(&b, v)
-//	// outputs to strings builder
-func Fdump(w io.Writer, vs ...any) {
-	NewDumper(WithWriter(w)).Dump(vs...)
+//	// Default: none
+//	type Report struct {
+//		Rows []int
+//	}
+//	d := godump.NewDumper(
+//		godump.WithLazyFormatter(reflect.TypeOf(Report{}), func(v reflect.Value) string {
+//			return fmt.Sprintf("Report(%d rows)", v.FieldByName("Rows").Len())
+//		}),
+//	)
+//	d.Dump(Report{Rows: []int{1, 2, 3}})
+//	// Report(3 rows) #godump.Report
+func WithLazyFormatter(t reflect.Type, fn func(reflect.Value) string) Option {
+	return func(d *Dumper) *Dumper {
+		if t == nil || fn == nil {
+			return d
+		}
+		formatters := make(map[reflect.Type]func(reflect.Value) string, len(d.lazyFormatters)+1)
+		for k, v := range d.lazyFormatters {
+			formatters[k] = v
+		}
+		formatters[t] = fn
+		d.lazyFormatters = formatters
+		return d
+	}
 }
 
-// DumpStr returns a string representation of the values with colorized output.
-// @group Dump
+// WithDrainChannels renders the items buffered in a channel by receiving them.
+// This is destructive: the items are consumed and will not reach the channel's
+// readers, so only use it to debug stuck pipelines, e.g. in tests. Up to max items
+// are received; the rest stay in the channel. Send-only and empty channels render as usual.
+// @group Options
 //
-// Example: get a string dump
+// Example: inspect a buffered channel
 //
-//	v := map[string]int{"a": 1}
-//	out := godump.DumpStr(v)
-//	godump.Dump(out)
-//	// "#map[string]int {\n  a => 1 #int\n}" #string
-func DumpStr(vs ...any) string {
-	return defaultDumper.DumpStr(vs...)
+//	// Default: false
+//	ch := make(chan int, 2)
+//	ch <- 1
+//	ch <- 2
+//	d := godump.NewDumper(godump.WithDrainChannels())
+//	d.Dump(ch)
+//	// chan int(0xc000012345) [
+//	//   0 => 1 #int
+//	//   1 => 2 #int
+//	// ] (2 drained)
+func WithDrainChannels() Option {
+	return func(d *Dumper) *Dumper {
+		d.drainChannels = true
+		return d
+	}
 }
 
-// DumpStr returns a string representation of the values with colorized output.
-// @group Dump
+// WithCycleFullPath adds the path of the original occurrence to each ↩︎ reference,
+// so cycles and shared pointers show where they point, e.g. ↩︎ &1 ($.Parent).
+// Paths are rooted at "$" like those passed to WithCallbackOnTruncate.
+// @group Options
 //
-// Example: get a string dump with a custom dumper
+// Example: show where a cycle points
 //
-//	d := godump.NewDumper()
-//	v := map[string]int{"a": 1}
-//	out := d.DumpStr(v)
-//	_ = out
-//	// "#map[string]int {\n  a => 1 #int\n}" #string
-func (d *Dumper) DumpStr(vs ...any) string {
-	local := d.clone()
-	state := newDumpState()
-	var sb strings.Builder
-	// local.printDumpHeader(&sb)
-	tw := tabwriter.NewWriter(&sb, 0, 0, 1, ' ', 0)
-	local.writeDump(tw, state, vs...)
-	tw.Flush()
-	return sb.String()
+//	// Default: false
+//	type Node struct {
+//		Name string
+//		Next *Node
+//	}
+//	a := &Node{Name: "a"}
+//	a.Next = &Node{Name: "b", Next: a}
+//	d := godump.NewDumper(godump.WithCycleFullPath())
+//	d.Dump(a)
+//	// #*godump.Node {
+//	//   +Name => "a" #string
+//	//   +Next => #*godump.Node {
+//	//     +Name => "b" #string
+//	//     +Next => #*godump.Node {
+//	//       +Name => "a" #string
+//	//       +Next => ↩︎ &1 ($.Next)
+//	//     }
+//	//   }
+//	// }
+func WithCycleFullPath() Option {
+	return func(d *Dumper) *Dumper {
+		d.cycleFullPath = true
+		return d
+	}
 }
 
-// DumpJSONStr pretty-prints values as JSON and returns it as a string.
-// @group JSON
+// WithComparableKeyDedup shows map values that point to an already dumped object as
+// ↩︎ references, revealing structure shared between keys, e.g. in caches. Keys are
+// visited in sorted order so the first key owning a pointer gets the full dump.
+// @group Options
 //
-// Example: dump JSON string
+// Example: reveal shared cache entries
 //
-//	v := map[string]int{"a": 1}
-//	d := godump.NewDumper()
-//	out := d.DumpJSONStr(v)
-//	_ = out
-//	// {"a":1}
-func (d *Dumper) DumpJSONStr(vs ...any) string {
-	if len(vs) == 0 {
-		return `{"error": "DumpJSON called with no arguments"}`
+//	// Default: false
+//	type Entry struct {
+//		ID int
+//	}
+//	shared := &Entry{ID: 1}
+//	d := godump.NewDumper(godump.WithComparableKeyDedup())
+//	d.Dump(map[string]*Entry{"a": shared, "b": shared})
+//	// #map[string]*godump.Entry {
+//	//    a => #*godump.Entry {
+//	//     +ID => 1 #int
+//	//   }
+//	//    b => ↩︎ &1
+//	// }
+func WithComparableKeyDedup() Option {
+	return func(d *Dumper) *Dumper {
+		d.mapValueDedup = true
+		return d
 	}
+}
 
-	var data any = vs
-	if len(vs) == 1 {
-		data = vs[0]
+// WithCustomBoolStrings renders booleans as t and f instead of true and false.
+// The colors for true and false values are unchanged.
+// @group Options
+//
+// Example: yes/no booleans
+//
+//	// Default: "true", "false"
+//	type Flags struct {
+//		Enabled bool
+//		Beta    bool
+//	}
+//	d := godump.NewDumper(godump.WithCustomBoolStrings("yes", "no"))
+//	d.Dump(Flags{Enabled: true})
+//	// #godump.Flags {
+//	//   +Enabled => yes #bool
+//	//   +Beta    => no #bool
+//	// }
+func WithCustomBoolStrings(t, f string) Option {
+	return func(d *Dumper) *Dumper {
+		d.trueString = t
+		d.falseString = f
+		return d
 	}
+}
 
-	b, err := json.MarshalIndent(data, "", strings.Repeat(" ", indentWidth))
-	if err != nil {
-		//nolint:errchkjson // fallback handles this manually below
-		errorJSON, _ := json.Marshal(map[string]string{"error": err.Error()})
-		return string(errorJSON)
+// WithShowMethods lists the exported method set of structs in a methods: section after
+// their fields. Structs reached through a pointer list the pointer's method set.
+// The list is bounded by WithMaxItems.
+// @group Options
+//
+// Example: list methods
+//
+//	// Default: false
+//	d := godump.NewDumper(godump.WithShowMethods())
+//	d.Dump(&strings.Builder{})
+//	// #*strings.Builder {
+//	//   -addr => *strings.Builder(nil)
+//	//   -buf  => []uint8(nil)
+//	//   methods:
+//	//     Cap() int
+//	//     Grow(int)
+//	//     ...
+//	// }
+func WithShowMethods() Option {
+	return func(d *Dumper) *Dumper {
+		d.showMethods = true
+		return d
 	}
-	return string(b)
 }
 
-// DumpJSON prints a pretty-printed JSON string to the configured writer.
-// @group JSON
+// WithDepthNumbers prefixes every output line with its nesting depth, e.g. [2], to help
+// keep track of very deep structures while scrolling.
+// @group Options
 //
-// Example: print JSON
+// Example: number nesting depth
 //
-//	v := map[string]int{"a": 1}
-//	d := godump.NewDumper()
-//	d.DumpJSON(v)
-//	// {
-//	//   "a": 1
+//	// Default: false
+//	type Inner struct {
+//		ID int
+//	}
+//	type Outer struct {
+//		Inner Inner
+//	}
+//	d := godump.NewDumper(godump.WithDepthNumbers())
+//	d.Dump(Outer{Inner: Inner{ID: 1}})
+//	// [0] #godump.Outer {
+//	// [1]   +Inner => #godump.Inner {
+//	// [2]     +ID => 1 #int
+//	// [1]   }
+//	// [0] }
+func WithDepthNumbers() Option {
+	return func(d *Dumper) *Dumper {
+		d.depthNumbers = true
+		return d
+	}
+}
+
+// WithQuoteMapKeys controls whether string map keys are rendered in double quotes.
+// Slice and field string values are always quoted; this only affects map keys.
+// @group Options
+//
+// Example: quote map keys
+//
+//	// Default: false
+//	v := map[string]int{"a b": 1}
+//	d := godump.NewDumper(godump.WithQuoteMapKeys(true))
+//	d.Dump(v)
+//	// #map[string]int {
+//	//    "a b" => 1 #int
 //	// }
-func (d *Dumper) DumpJSON(vs ...any) {
-	output := d.DumpJSONStr(vs...)
-	fmt.Fprintln(d.writer, output)
+func WithQuoteMapKeys(quote bool) Option {
+	return func(d *Dumper) *Dumper {
+		d.quoteMapKeys = quote
+		return d
+	}
 }
 
-// DumpHTML dumps the values as HTML with colorized output.
-// @group HTML
+// WithTrimLongTypeParams abbreviates generic type arguments in type markers once the
+// argument list is longer than n characters, keeping the base type name and any leading
+// arguments that fit, e.g. #godump.Cache[string, …]. Param n must be greater than 0 or this will be ignored.
+// @group Options
 //
-// Example: dump HTML
+// Example: shorten generic type markers
 //
-//	v := map[string]int{"a": 1}
-//	html := godump.DumpHTML(v)
-//	_ = html
-//	// (html output)
-func DumpHTML(vs ...any) string {
-	return defaultDumper.DumpHTML(vs...)
+//	// Default: 0 (no trimming)
+//	var p atomic.Pointer[map[string][]int]
+//	d := godump.NewDumper(godump.WithTrimLongTypeParams(10))
+//	d.Dump(&p)
+//	// #*atomic.Pointer[…] {
+//	//   ...
+//	// }
+func WithTrimLongTypeParams(n int) Option {
+	return func(d *Dumper) *Dumper {
+		if n > 0 {
+			d.maxTypeParamsLen = n
+		}
+		return d
+	}
 }
 
-// DumpHTML dumps the values as HTML with colorized output.
-// @group HTML
+// WithRenderZeroPointersAsNil renders non-nil pointers to zero values inline as
+// *Type(zero) instead of expanding the pointee. Nil pointers are unaffected.
+// @group Options
 //
-// Example: dump HTML with a custom dumper
+// Example: collapse pointers to zero values
 //
-//	d := godump.NewDumper()
-//	v := map[string]int{"a": 1}
-//	html := d.DumpHTML(v)
-//	_ = html
-//	fmt.Println(html)
-//	// (html output)
-func (d *Dumper) DumpHTML(vs ...any) string {
-	var sb strings.Builder
-	sb.WriteString(`<div style='background-color:black;'><pre style="background-color:black; color:white; padding:5px; border-radius: 5px">` + "\n")
+//	// Default: false
+//	type Limits struct {
+//		Max int
+//	}
+//	type Config struct {
+//		Limits *Limits
+//	}
+//	d := godump.NewDumper(godump.WithRenderZeroPointersAsNil())
+//	d.Dump(Config{Limits: &Limits{}})
+//	// #godump.Config {
+//	//   +Limits => *godump.Limits(zero)
+//	// }
+func WithRenderZeroPointersAsNil() Option {
+	return func(d *Dumper) *Dumper {
+		d.zeroPointersAsNil = true
+		return d
+	}
+}
 
-	htmlDumper := d.clone()
-	if !htmlDumper.disableColor {
-		htmlDumper.colorizer = colorizeHTML // use HTML colorizer
+// WithInterfaceMethodSets shows the method names of an interface type next to nil values of
+// that type, e.g. io.Writer(nil) {Write}, so the expected shape is visible.
+// Only values whose static interface type is known, like struct fields or pointed-to
+// variables, carry it; a bare nil passed to Dump has no type.
+// @group Options
+//
+// Example: show the methods of nil interface fields
+//
+//	// Default: false
+//	type Sink struct {
+//		Out io.Writer
+//	}
+//	d := godump.NewDumper(godump.WithInterfaceMethodSets())
+//	d.Dump(Sink{})
+//	// #godump.Sink {
+//	//   +Out => io.Writer(nil) {Write}
+//	// }
+func WithInterfaceMethodSets() Option {
+	return func(d *Dumper) *Dumper {
+		d.interfaceMethodSets = true
+		return d
 	}
+}
 
-	sb.WriteString(htmlDumper.DumpStr(vs...))
+// WithJSONMarshalerRendering renders types implementing json.Marshaler by their indented
+// MarshalJSON output instead of their fields, so the dump matches what gets serialized.
+// Values whose MarshalJSON fails are dumped normally.
+// @group Options
+//
+// Example: show a custom JSON shape
+//
+//	// Default: false
+//	v := json.RawMessage(`{"id":1}`)
+//	d := godump.NewDumper(godump.WithJSONMarshalerRendering())
+//	d.Dump(v)
+//	// {
+//	//   "id": 1
+//	// } #json.RawMessage
+func WithJSONMarshalerRendering() Option {
+	return func(d *Dumper) *Dumper {
+		d.jsonMarshalers = true
+		return d
+	}
+}
 
-	sb.WriteString("</pre></div>")
-	return sb.String()
+// WithObfuscateValues hides real data for sharing dumps in bug reports or screenshots.
+// Strings become runs of "x" of the same length, digits become "9" so numbers keep
+// their magnitude, and Stringer output is masked the same way. Field names, map keys,
+// and types are kept, and the output is deterministic, so repeated dumps match.
+// @group Options
+//
+// Example: share a dump without its data
+//
+//	// Default: false
+//	type User struct {
+//		Email string
+//		Age   int
+//	}
+//	d := godump.NewDumper(godump.WithObfuscateValues())
+//	d.Dump(User{Email: "ada@example.com", Age: 36})
+//	// #godump.User {
+//	//   +Email => "xxxxxxxxxxxxxxx" #string
+//	//   +Age   => 99 #int
+//	// }
+func WithObfuscateValues() Option {
+	return func(d *Dumper) *Dumper {
+		d.obfuscateValues = true
+		return d
+	}
 }
 
-// DumpJSON dumps the values as a pretty-printed JSON string.
-// If there is more than one value, they are dumped as a JSON array.
-// It returns an error string if marshaling fails.
-// @group JSON
+// WithTimeFormat renders time.Time values with the given layout wherever they appear,
+// including inside slices, arrays, and maps, instead of their String form.
+// The zero time renders as time.Time(zero).
+// @group Options
 //
-// Example: print JSON
+// Example: show times as RFC 3339
 //
-//	v := map[string]int{"a": 1}
-//	godump.DumpJSON(v)
-//	// {
-//	//   "a": 1
+//	// Default: "" (time.Time.String)
+//	v := []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+//	d := godump.NewDumper(godump.WithTimeFormat(time.RFC3339))
+//	d.Dump(v)
+//	// #[]time.Time [
+//	//   0 => 2024-01-02T03:04:05Z #time.Time
+//	// ]
+func WithTimeFormat(layout string) Option {
+	return func(d *Dumper) *Dumper {
+		d.timeFormat = layout
+		return d
+	}
+}
+
+// WithRenderFunc registers fn to be called for every value before any other formatting.
+// Returning ok=true prints the returned string in place of the value and its whole
+// subtree; returning false renders the value normally. indent is the nesting depth,
+// for aligning multi-line output. Unlike type formatters, fn can decide per value.
+// @group Options
+//
+// Example: mask long strings
+//
+//	// Default: nil
+//	d := godump.NewDumper(godump.WithRenderFunc(func(v reflect.Value, indent int) (string, bool) {
+//		if v.Kind() == reflect.String && v.Len() > 8 {
+//			return fmt.Sprintf("<%d chars>", v.Len()), true
+//		}
+//		return "", false
+//	}))
+//	d.Dump([]string{"short", "a much longer string"})
+//	// #[]string [
+//	//   0 => "short" #string
+//	//   1 => <20 chars>
+//	// ]
+func WithRenderFunc(fn func(v reflect.Value, indent int) (string, bool)) Option {
+	return func(d *Dumper) *Dumper {
+		d.renderFunc = fn
+		return d
+	}
+}
+
+// WithFieldMatchMode sets how field names are matched for WithExcludeFields.
+// @group Options
+//
+// Example: use substring matching
+//
+//	// Default: FieldMatchExact
+//	type User struct {
+//		UserID int
+//	}
+//	d := godump.NewDumper(
+//		godump.WithExcludeFields("id"),
+//		godump.WithFieldMatchMode(godump.FieldMatchContains),
+//	)
+//	d.Dump(User{UserID: 10})
+//	// #godump.User {
 //	// }
-func DumpJSON(vs ...any) {
-	defaultDumper.DumpJSON(vs...)
+func WithFieldMatchMode(mode FieldMatchMode) Option {
+	return func(d *Dumper) *Dumper {
+		d.fieldMatchMode = mode
+		return d
+	}
 }
 
-// DumpJSONStr dumps the values as a JSON string.
-// @group JSON
+// WithRedactFields replaces matching struct fields with a redacted placeholder.
+// @group Options
 //
-// Example: JSON string
+// Example: redact fields
 //
-//	v := map[string]int{"a": 1}
-//	out := godump.DumpJSONStr(v)
-//	_ = out
-//	// {"a":1}
-func DumpJSONStr(vs ...any) string {
-	return defaultDumper.DumpJSONStr(vs...)
+//	// Default: none
+//	type User struct {
+//		ID       int
+//		Password string
+//	}
+//	d := godump.NewDumper(
+//		godump.WithRedactFields("Password"),
+//	)
+//	d.Dump(User{ID: 1, Password: "secret"})
+//	// #godump.User {
+//	//   +ID       => 1 #int
+//	//   +Password => <redacted> #string
+//	// }
+func WithRedactFields(names ...string) Option {
+	return func(d *Dumper) *Dumper {
+		d.redactFields = append(d.redactFields, names...)
+		return d
+	}
 }
 
-// Dd is a debug function that prints the values and exits the program.
-// @group Dump
+// WithRedactSensitive enables default redaction for common sensitive fields.
+// @group Options
 //
-// Example: dump and exit
+// Example: redact common sensitive fields
 //
-//	v := map[string]int{"a": 1}
-//	godump.Dd(v)
-//	// #map[string]int {
-//	//   a => 1 #int
+//	// Default: disabled
+//	type User struct {
+//		Password string
+//		Token    string
+//	}
+//	d := godump.NewDumper(
+//		godump.WithRedactSensitive(),
+//	)
+//	d.Dump(User{Password: "secret", Token: "abc"})
+//	// #godump.User {
+//	//   +Password => <redacted> #string
+//	//   +Token    => <redacted> #string
 //	// }
-func Dd(vs ...any) {
-	defaultDumper.Dd(vs...)
+func WithRedactSensitive() Option {
+	return func(d *Dumper) *Dumper {
+		d.redactFields = append(d.redactFields, defaultRedactedFields...)
+		d.redactMatchMode = FieldMatchContains
+		return d
+	}
 }
 
-// Dd is a debug function that prints the values and exits the program.
-// @group Debug
+// WithRedactRegex redacts struct fields and string map keys whose name matches the pattern.
+// The pattern is compiled once when the option is created and panics if it is invalid,
+// like regexp.MustCompile, since dumper options are expected to be static.
+// @group Options
 //
-// Example: dump and exit with a custom dumper
+// Example: redact by pattern
 //
-//	d := godump.NewDumper()
-//	v := map[string]int{"a": 1}
-//	d.Dd(v)
-//	// #map[string]int {
-//	//   a => 1 #int
+//	// Default: none
+//	type Config struct {
+//		DBSecret string
+//		Host     string
+//	}
+//	d := godump.NewDumper(
+//		godump.WithRedactRegex(`(?i)secret`),
+//	)
+//	d.Dump(Config{DBSecret: "hunter2", Host: "localhost"})
+//	// #godump.Config {
+//	//   +DBSecret => <redacted> #string
+//	//   +Host     => "localhost" #string
 //	// }
-func (d *Dumper) Dd(vs ...any) {
-	d.Dump(vs...)
-	exitFunc(1)
+func WithRedactRegex(pattern string) Option {
+	re := regexp.MustCompile(pattern)
+	return func(d *Dumper) *Dumper {
+		d.redactPatterns = append(d.redactPatterns, re)
+		return d
+	}
 }
 
-// clone creates a copy of the [Dumper] with the same configuration.
-// This is useful for creating a new dumper with the same settings without modifying the original.
-func (d *Dumper) clone() *Dumper {
-	newDumper := *d
-	return &newDumper
+// WithRedactMatchMode sets how field names are matched for WithRedactFields.
+// @group Options
+//
+// Example: use substring matching
+//
+//	// Default: FieldMatchExact
+//	type User struct {
+//		APIKey string
+//	}
+//	d := godump.NewDumper(
+//		godump.WithRedactFields("key"),
+//		godump.WithRedactMatchMode(godump.FieldMatchContains),
+//	)
+//	d.Dump(User{APIKey: "abc"})
+//	// #godump.User {
+//	//   +APIKey => <redacted> #string
+//	// }
+func WithRedactMatchMode(mode FieldMatchMode) Option {
+	return func(d *Dumper) *Dumper {
+		d.redactMatchMode = mode
+		return d
+	}
+}
+
+// WithThousandsSeparator groups the digits of integers and the integer part of floats.
+// Param sep is inserted between every group of three digits; 0 disables grouping.
+// @group Options
+//
+// Example: group digits
+//
+//	// Default: disabled
+//	d := godump.NewDumper(godump.WithThousandsSeparator(','))
+//	d.Dump(1000000)
+//	// 1,000,000 #int
+func WithThousandsSeparator(sep rune) Option {
+	return func(d *Dumper) *Dumper {
+		d.thousandsSep = sep
+		return d
+	}
+}
+
+// WithNumberLocale sets the digit grouping and decimal point characters for integers and
+// floats, e.g. '.' and ',' for European style. A grouping of 0 disables grouping, and a
+// decimal of 0 keeps '.'.
+// @group Options
+//
+// Example: European number format
+//
+//	// Default: no grouping, '.' decimal point
+//	d := godump.NewDumper(godump.WithNumberLocale('.', ','))
+//	d.Dump(1000000.5)
+//	// 1.000.000,500000 #float64
+func WithNumberLocale(grouping, decimal rune) Option {
+	return func(d *Dumper) *Dumper {
+		d.thousandsSep = grouping
+		d.decimalSep = decimal
+		return d
+	}
+}
+
+// WithHTMLDataAttributes wraps every value in DumpHTML output with data-type and data-path attributes.
+// This lets client-side scripts build collapsible trees or link values back to their path.
+// @group Options
+//
+// Example: annotate HTML output
+//
+//	// Default: false
+//	type User struct {
+//		Name string
+//	}
+//	d := godump.NewDumper(godump.WithHTMLDataAttributes())
+//	html := d.DumpHTML(User{Name: "Alice"})
+//	_ = html
+//	// <span data-type="string" data-path="$.Name">...</span>
+func WithHTMLDataAttributes() Option {
+	return func(d *Dumper) *Dumper {
+		d.htmlDataAttrs = true
+		return d
+	}
+}
+
+// WithMatrixView renders rectangular slices of scalar slices as an aligned grid.
+// Ragged or non-scalar nested slices keep the regular rendering.
+// @group Options
+//
+// Example: render a matrix
+//
+//	// Default: false
+//	v := [][]int{{1, 2, 3}, {10, 20, 30}}
+//	d := godump.NewDumper(godump.WithMatrixView())
+//	d.Dump(v)
+//	// #[][]int [
+//	//   [ 1  2  3]
+//	//   [10 20 30]
+//	// ]
+func WithMatrixView() Option {
+	return func(d *Dumper) *Dumper {
+		d.matrixView = true
+		return d
+	}
+}
+
+// WithNilFormatter customizes how nil pointers, maps, slices, channels, funcs, and interfaces render.
+// The function receives the static type of the nil value; its result replaces the default "type(nil)".
+// @group Options
+//
+// Example: distinguish nil kinds
+//
+//	// Default: type(nil)
+//	d := godump.NewDumper(godump.WithNilFormatter(func(t reflect.Type) string {
+//		return "<nil " + t.Kind().String() + ">"
+//	}))
+//	var m map[string]int
+//	d.Dump(m)
+//	// <nil map>
+func WithNilFormatter(fn func(t reflect.Type) string) Option {
+	return func(d *Dumper) *Dumper {
+		d.nilFormatter = fn
+		return d
+	}
+}
+
+// WithFSListing renders values implementing fs.FS, such as embed.FS, as a listing of their files.
+// Each entry shows its path and size; the walk stops after MaxItems entries.
+// @group Options
+//
+// Example: list an embedded filesystem
+//
+//	// Default: false
+//	fsys := fstest.MapFS{"index.html": {Data: []byte("<html></html>")}}
+//	d := godump.NewDumper(godump.WithFSListing())
+//	d.Dump(fsys)
+//	// #map[string]*fstest.MapFile {
+//	//   index.html => 13 bytes
+//	// }
+func WithFSListing() Option {
+	return func(d *Dumper) *Dumper {
+		d.fsListing = true
+		return d
+	}
+}
+
+// WithPointerIDs labels every pointer with an &N anchor the first time it is printed.
+// Later occurrences of the same pointer render as ↩︎ &N, so aliased fields are easy to spot.
+// @group Options
+//
+// Example: label pointers
+//
+//	// Default: false
+//	type Pair struct {
+//		A, B *int
+//	}
+//	n := 1
+//	d := godump.NewDumper(godump.WithPointerIDs())
+//	d.Dump(Pair{A: &n, B: &n})
+//	// #godump.Pair {
+//	//   +A => &1 1 #*int
+//	//   +B => ↩︎ &1
+//	// }
+func WithPointerIDs() Option {
+	return func(d *Dumper) *Dumper {
+		d.pointerIDs = true
+		return d
+	}
+}
+
+// WithEmptyJSON sets what DumpJSON and DumpJSONStr return when called with no arguments.
+// Param value must be valid JSON or this will be ignored, and the default error object is kept.
+// @group Options
+//
+// Example: return an empty array
+//
+//	// Default: {"error": "DumpJSON called with no arguments"}
+//	d := godump.NewDumper(godump.WithEmptyJSON("[]"))
+//	out := d.DumpJSONStr()
+//	fmt.Println(out)
+//	// []
+func WithEmptyJSON(value string) Option {
+	return func(d *Dumper) *Dumper {
+		if json.Valid([]byte(value)) {
+			d.emptyJSON = value
+		}
+		return d
+	}
+}
+
+// WithMarkPointers prefixes values reached through pointers with one * per pointer level.
+// This makes *map[string]int and *[]int stand out from the map or slice itself at a glance.
+// @group Options
+//
+// Example: mark pointer values
+//
+//	// Default: false
+//	v := &[]int{1}
+//	d := godump.NewDumper(godump.WithMarkPointers())
+//	d.Dump(v)
+//	// *#*[]int [
+//	//   0 => 1 #int
+//	// ]
+func WithMarkPointers() Option {
+	return func(d *Dumper) *Dumper {
+		d.markPointers = true
+		return d
+	}
+}
+
+// WithStructFieldCount shows how many fields a struct renders next to its type name.
+// The count reflects field filters such as WithExcludeFields.
+// @group Options
+//
+// Example: show field counts
+//
+//	// Default: false
+//	type User struct {
+//		ID   int
+//		Name string
+//	}
+//	d := godump.NewDumper(godump.WithStructFieldCount())
+//	d.Dump(User{ID: 1, Name: "Alice"})
+//	// #godump.User (2 fields) {
+//	//   +ID   => 1 #int
+//	//   +Name => "Alice" #string
+//	// }
+func WithStructFieldCount() Option {
+	return func(d *Dumper) *Dumper {
+		d.structFieldCount = true
+		return d
+	}
+}
+
+// WithRawMode renders every value by its underlying kind, showing the actual fields.
+// It bypasses fmt.Stringer and all built-in type formatters.
+// @group Options
+//
+// Example: show raw structure
+//
+//	// Default: false
+//	v := time.Duration(3)
+//	d := godump.NewDumper(godump.WithRawMode())
+//	d.Dump(v)
+//	// 3 #time.Duration
+func WithRawMode() Option {
+	return func(d *Dumper) *Dumper {
+		d.rawMode = true
+		return d
+	}
+}
+
+// WithCollapseSingleFieldStructs renders structs with a single exported scalar field inline.
+// Newtype-style wrappers print as #Type(value) instead of a full block.
+// @group Options
+//
+// Example: collapse wrapper structs
+//
+//	// Default: false
+//	type Celsius struct {
+//		V float64
+//	}
+//	d := godump.NewDumper(godump.WithCollapseSingleFieldStructs())
+//	d.Dump(Celsius{V: 21.5})
+//	// #godump.Celsius(21.500000)
+func WithCollapseSingleFieldStructs() Option {
+	return func(d *Dumper) *Dumper {
+		d.collapseSingle = true
+		return d
+	}
+}
+
+// WithHideProtoInternals hides the bookkeeping fields of generated protobuf messages.
+// Messages are detected by their Reset, String, and ProtoReflect methods, so protobuf isn't imported.
+// Fields like state, sizeCache, unknownFields, XXX_*, and protoimpl-typed fields are skipped.
+// @group Options
+//
+// Example: hide protobuf noise
+//
+//	// Default: false
+//	var msg any // a generated *pb.User{Name: "Alice"}
+//	d := godump.NewDumper(godump.WithHideProtoInternals())
+//	d.Dump(msg)
+//	// #*pb.User {
+//	//   +Name => "Alice" #string
+//	// }
+func WithHideProtoInternals() Option {
+	return func(d *Dumper) *Dumper {
+		d.hideProtoInternals = true
+		return d
+	}
+}
+
+// WithPackageColors colors #pkg.Type markers by package, so types from the same package share a color.
+// Colors come from the theme's PackageColors, keyed by a hash of the package path, so they are stable across runs.
+// @group Options
+//
+// Example: color types by package
+//
+//	// Default: false
+//	v := map[string]time.Duration{"a": time.Second}
+//	d := godump.NewDumper(godump.WithPackageColors())
+//	d.Dump(v)
+//	// #map[string]time.Duration {
+//	//   a => 1s #time.Duration
+//	// }
+func WithPackageColors() Option {
+	return func(d *Dumper) *Dumper {
+		d.packageColors = true
+		return d
+	}
+}
+
+// WithContainerSequences renders container/list and container/ring values as their element sequences.
+// Lists print front to back and rings once around, instead of their internal node pointers.
+// @group Options
+//
+// Example: dump a linked list
+//
+//	// Default: false
+//	l := list.New()
+//	l.PushBack(1)
+//	l.PushBack(2)
+//	d := godump.NewDumper(godump.WithContainerSequences())
+//	d.Dump(l)
+//	// #*list.List [
+//	//   0 => 1 #int
+//	//   1 => 2 #int
+//	// ]
+func WithContainerSequences() Option {
+	return func(d *Dumper) *Dumper {
+		d.containerSequences = true
+		return d
+	}
+}
+
+// WithSkipStdlibInternals renders common standard library types readably instead of
+// exposing their unexported internals. On top of the types godump always formats
+// (time.Time, time.Duration, time.Timer, time.Ticker, netip, and *regexp.Regexp), it covers:
+//   - sync.Mutex, sync.RWMutex, sync.WaitGroup, and sync.Once as placeholders
+//   - url.URL values as their string form
+//   - reflect.Value as its scalar value or type
+//   - container/list and container/ring as element sequences (see WithContainerSequences)
+//
+// @group Options
+//
+// Example: hide stdlib internals
+//
+//	// Default: false
+//	type Service struct {
+//		mu       sync.Mutex
+//		Endpoint url.URL
+//	}
+//	d := godump.NewDumper(godump.WithSkipStdlibInternals())
+//	d.Dump(&Service{Endpoint: url.URL{Scheme: "https", Host: "example.com"}})
+//	// #*godump.Service {
+//	//   -mu       => sync.Mutex{…} #sync.Mutex
+//	//   +Endpoint => https://example.com #url.URL
+//	// }
+func WithSkipStdlibInternals() Option {
+	return func(d *Dumper) *Dumper {
+		d.skipStdlibInternals = true
+		d.containerSequences = true
+		return d
+	}
+}
+
+// NewDumper creates a new Dumper with the given options applied.
+// Defaults are used for any setting not overridden.
+// @group Builder
+//
+// Example: build a custom dumper
+//
+//	v := map[string]int{"a": 1}
+//	d := godump.NewDumper(
+//		godump.WithMaxDepth(10),
+//		godump.WithWriter(os.Stdout),
+//	)
+//	d.Dump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func NewDumper(opts ...Option) *Dumper {
+	d := &Dumper{
+		maxDepth:        defaultMaxDepth,
+		maxItems:        defaultMaxItems,
+		maxStringLen:    defaultMaxStringLen,
+		disableStringer: defaultDisableStringer,
+		writer:          os.Stdout,
+		colorizer:       nil, // ensure no detection is made if we don't need it
+		callerFn:        runtime.Caller,
+		emptyJSON:       defaultEmptyJSON,
+		fieldMatchMode:  FieldMatchExact,
+		redactMatchMode: FieldMatchExact,
+		sortMapKeys:     true,
+		indentWidth:     defaultIndentWidth,
+		theme:           DefaultTheme(),
+		hexGroupSize:    defaultHexGroupSize,
+	}
+	for _, opt := range opts {
+		d = opt(d)
+	}
+	return d
+}
+
+// Dump prints the values to stdout with colorized output.
+// @group Dump
+//
+// Example: print to stdout
+//
+//	v := map[string]int{"a": 1}
+//	godump.Dump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func Dump(vs ...any) {
+	defaultDumper.Dump(vs...)
+}
+
+// Dump prints the values to stdout with colorized output.
+// @group Dump
+//
+// Example: print with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := map[string]int{"a": 1}
+//	d.Dump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func (d *Dumper) Dump(vs ...any) {
+	fmt.Fprint(d.writer, d.DumpStr(vs...))
+	d.writeExtra(func(plain *Dumper) string { return plain.DumpStr(vs...) })
+	d.flush()
+}
+
+// writeExtra renders output without colors and writes it to every WithExtraWriter destination.
+func (d *Dumper) writeExtra(render func(plain *Dumper) string) {
+	if len(d.extraWriters) == 0 {
+		return
+	}
+	plain := d.clone()
+	plain.disableColor = true
+	plain.colorizer = colorizeUnstyled
+	out := render(plain)
+	for _, w := range d.extraWriters {
+		fmt.Fprint(w, out)
+	}
+}
+
+// flusher is implemented by buffered writers such as *bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// flush flushes the writer and every extra writer that buffers output when WithAutoFlush is enabled.
+func (d *Dumper) flush() {
+	if !d.autoFlush {
+		return
+	}
+	if f, ok := d.writer.(flusher); ok {
+		f.Flush()
+	}
+	for _, w := range d.extraWriters {
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+// DumpGroup prints a labeled banner and indents everything fn dumps beneath it,
+// so related values cluster together in verbose output.
+// @group Dump
+//
+// Example: group related dumps
+//
+//	godump.DumpGroup("request", func(d *godump.Dumper) {
+//		d.Dump("GET")
+//		d.Dump(200)
+//	})
+//	// #group "request" {
+//	//   "GET" #string
+//	//   200 #int
+//	// }
+func DumpGroup(label string, fn func(d *Dumper)) {
+	defaultDumper.DumpGroup(label, fn)
+}
+
+// DumpGroup prints a labeled banner to the configured writer and calls fn with a
+// dumper that shares d's configuration but writes one level further indented.
+// Groups can be nested by calling DumpGroup on the dumper passed to fn.
+// @group Dump
+//
+// Example: group related dumps
+//
+//	d := godump.NewDumper()
+//	d.DumpGroup("user", func(d *godump.Dumper) {
+//		d.Dump(map[string]int{"id": 1})
+//	})
+//	// #group "user" {
+//	//   #map[string]int {
+//	//     id => 1 #int
+//	//   }
+//	// }
+func (d *Dumper) DumpGroup(label string, fn func(d *Dumper)) {
+	d.ensureColorizer()
+	header := d.colorize(d.theme.MetaColor, "#group "+strconv.Quote(label)) + " {\n"
+	d.writeAll(header)
+
+	inner := d.clone()
+	inner.writer = &indentWriter{w: d.writer, prefix: d.indentString(1), lineStart: true}
+	inner.extraWriters = make([]io.Writer, len(d.extraWriters))
+	for i, w := range d.extraWriters {
+		inner.extraWriters[i] = &indentWriter{w: w, prefix: d.indentString(1), lineStart: true}
+	}
+	fn(inner)
+
+	d.writeAll("}\n")
+	d.flush()
+}
+
+// writeAll writes s to the writer and, without colors, to every WithExtraWriter destination.
+func (d *Dumper) writeAll(s string) {
+	io.WriteString(d.writer, s)
+	if len(d.extraWriters) > 0 {
+		plain := stripANSI(s)
+		for _, w := range d.extraWriters {
+			io.WriteString(w, plain)
+		}
+	}
+}
+
+// indentWriter prefixes every line written through it, for DumpGroup.
+type indentWriter struct {
+	w         io.Writer
+	prefix    string
+	lineStart bool
+}
+
+func (iw *indentWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, 0, len(p)+len(iw.prefix))
+	for _, b := range p {
+		if iw.lineStart && b != '\n' {
+			buf = append(buf, iw.prefix...)
+		}
+		buf = append(buf, b)
+		iw.lineStart = b == '\n'
+	}
+	if _, err := iw.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush flushes the underlying writer, so WithAutoFlush works inside groups.
+func (iw *indentWriter) Flush() error {
+	if f, ok := iw.w.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// DumpSlice prints each element of a slice or array as its own top-level dump.
+// @group Dump
+//
+// Example: dump elements separately
+//
+//	v := []int{1, 2}
+//	godump.DumpSlice(v)
+//	// [0] 1 #int
+//	// [1] 2 #int
+func DumpSlice(s any) {
+	defaultDumper.DumpSlice(s)
+}
+
+// DumpSlice prints each element of a slice or array as its own top-level dump.
+// Non-slice input prints an error line instead of a dump.
+// @group Dump
+//
+// Example: dump elements separately with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := []string{"a", "b"}
+//	d.DumpSlice(v)
+//	// [0] "a" #string
+//	// [1] "b" #string
+func (d *Dumper) DumpSlice(s any) {
+	fmt.Fprint(d.writer, d.dumpSliceStr(s))
+	d.writeExtra(func(plain *Dumper) string { return plain.dumpSliceStr(s) })
+	d.flush()
+}
+
+// dumpSliceStr renders each element of s as an indexed top-level entry.
+func (d *Dumper) dumpSliceStr(s any) string {
+	local := d.clone()
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return local.colorize(local.theme.ErrorColor, fmt.Sprintf("DumpSlice: expected a slice or array, got %s", local.typeStringForAny(s))) + "\n"
+	}
+
+	state := newDumpState()
+	buf := getDumpBuffer()
+	defer putDumpBuffer(buf)
+	w, done := local.startLimits(buf.tw, state)
+	for i := 0; i < rv.Len(); i++ {
+		if local.limitHit(state) {
+			fmt.Fprintln(w, local.colorize(local.theme.MetaColor, fmt.Sprintf("... (%d more truncated)", rv.Len()-i)))
+			break
+		}
+		fmt.Fprint(w, local.colorize(local.theme.NumberColor, fmt.Sprintf("[%d]", i))+" ")
+		local.printValue(w, makeAddressable(rv.Index(i)), 0, state)
+		fmt.Fprintln(w)
+	}
+	done()
+	buf.tw.Flush()
+	return local.wrapToWidth(buf.out.String())
+}
+
+// DumpRaw prints the values by their underlying kinds, bypassing Stringer and type formatters.
+// @group Dump
+//
+// Example: dump raw structure
+//
+//	v := time.Duration(3)
+//	godump.DumpRaw(v)
+//	// 3 #time.Duration
+func DumpRaw(vs ...any) {
+	defaultDumper.DumpRaw(vs...)
+}
+
+// DumpRaw prints the values by their underlying kinds, bypassing Stringer and type formatters.
+// @group Dump
+//
+// Example: dump raw structure with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := time.Duration(3)
+//	d.DumpRaw(v)
+//	// 3 #time.Duration
+func (d *Dumper) DumpRaw(vs ...any) {
+	raw := d.clone()
+	raw.rawMode = true
+	raw.Dump(vs...)
+}
+
+// Fdump writes the formatted dump of values to the given io.Writer.
+// @group Dump
+//
+// Example: dump to writer
+//
+//	var b strings.Builder
+//	v := map[string]int{"a": 1}
+//	godump.Fdump(&b, v)
+//	// outputs to strings builder
+func Fdump(w io.Writer, vs ...any) {
+	NewDumper(WithWriter(w)).Dump(vs...)
+}
+
+// DumpN writes the formatted dump of values to w and returns the number of bytes
+// written and any write error, like fmt.Fprint.
+// @group Dump
+//
+// Example: dump to writer and check the result
+//
+//	var b strings.Builder
+//	n, err := godump.DumpN(&b, 1)
+//	_, _ = n, err
+//	// 7 <nil>
+func DumpN(w io.Writer, vs ...any) (int, error) {
+	return defaultDumper.DumpN(w, vs...)
+}
+
+// DumpN writes the formatted dump of values to w in a single write and returns the
+// number of bytes written and any write error.
+// @group Dump
+//
+// Example: dump to writer with a custom dumper
+//
+//	var b strings.Builder
+//	d := godump.NewDumper()
+//	n, err := d.DumpN(&b, 1)
+//	_, _ = n, err
+//	// 7 <nil>
+func (d *Dumper) DumpN(w io.Writer, vs ...any) (int, error) {
+	return io.WriteString(w, d.DumpStr(vs...))
+}
+
+// DumpStderr prints the values to stderr, keeping debug output out of a program's stdout.
+// @group Dump
+//
+// Example: dump to stderr
+//
+//	v := map[string]int{"a": 1}
+//	godump.DumpStderr(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func DumpStderr(vs ...any) {
+	NewDumper(WithWriter(os.Stderr)).Dump(vs...)
+}
+
+// Edump is a short alias for DumpStderr.
+// @group Dump
+//
+// Example: dump to stderr
+//
+//	v := map[string]int{"a": 1}
+//	godump.Edump(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func Edump(vs ...any) {
+	NewDumper(WithWriter(os.Stderr)).Dump(vs...)
+}
+
+// DumpStr returns a string representation of the values with colorized output.
+// @group Dump
+//
+// Example: get a string dump
+//
+//	v := map[string]int{"a": 1}
+//	out := godump.DumpStr(v)
+//	godump.Dump(out)
+//	// "#map[string]int {\n  a => 1 #int\n}" #string
+func DumpStr(vs ...any) string {
+	return defaultDumper.DumpStr(vs...)
+}
+
+// DumpStr returns a string representation of the values with colorized output.
+// @group Dump
+//
+// Example: get a string dump with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := map[string]int{"a": 1}
+//	out := d.DumpStr(v)
+//	_ = out
+//	// "#map[string]int {\n  a => 1 #int\n}" #string
+func (d *Dumper) DumpStr(vs ...any) string {
+	local := d.clone()
+	state := newDumpState()
+	buf := getDumpBuffer()
+	defer putDumpBuffer(buf)
+	// local.printDumpHeader(&buf.out)
+	local.writeDump(buf.tw, state, vs...)
+	buf.tw.Flush()
+	out := buf.out.String()
+	if local.headless && len(vs) == 1 {
+		out = local.stripOuterBlock(out)
+	}
+	return local.wrapToWidth(out)
+}
+
+// stripOuterBlock removes the opening "#Type {" line and closing brace of a single
+// composite dump and outdents its contents by one level. Other output is returned as is.
+func (d *Dumper) stripOuterBlock(out string) string {
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) < 2 {
+		return out
+	}
+	_, firstLine := d.splitDepthPrefix(lines[0])
+	_, lastLine := d.splitDepthPrefix(lines[len(lines)-1])
+	first := stripANSI(firstLine)
+	last := strings.TrimSpace(stripANSI(lastLine))
+	if !(strings.HasSuffix(first, " {") && last == "}") && !(strings.HasSuffix(first, " [") && last == "]") {
+		return out
+	}
+
+	var sb strings.Builder
+	for _, line := range lines[1 : len(lines)-1] {
+		if depth, rest := d.splitDepthPrefix(line); depth > 0 {
+			sb.WriteString(d.colorize(d.theme.MetaColor, fmt.Sprintf("[%d] ", depth-1)))
+			line = rest
+		}
+		sb.WriteString(strings.TrimPrefix(line, d.indentString(1)))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// splitDepthPrefix separates the "[n] " marker WithDepthNumbers puts before a line from
+// the rest of it. It returns -1 and the line unchanged when there is no marker.
+func (d *Dumper) splitDepthPrefix(line string) (int, string) {
+	if !d.depthNumbers {
+		return -1, line
+	}
+	plain := stripANSI(line)
+	end := strings.Index(plain, "] ")
+	if !strings.HasPrefix(plain, "[") || end < 0 {
+		return -1, line
+	}
+	depth, err := strconv.Atoi(plain[1:end])
+	if err != nil {
+		return -1, line
+	}
+	prefix := d.colorize(d.theme.MetaColor, fmt.Sprintf("[%d] ", depth))
+	if !strings.HasPrefix(line, prefix) {
+		return -1, line
+	}
+	return depth, line[len(prefix):]
+}
+
+// maxPooledBufferSize caps the buffers returned to dumpBufferPool so one huge dump doesn't pin memory.
+const maxPooledBufferSize = 64 << 10
+
+// dumpBuffer pairs an output buffer with the tabwriter that aligns into it.
+type dumpBuffer struct {
+	out bytes.Buffer
+	tw  *tabwriter.Writer
+}
+
+// dumpBufferPool reuses buffers and tabwriters across DumpStr calls.
+var dumpBufferPool = sync.Pool{
+	New: func() any {
+		return &dumpBuffer{tw: new(tabwriter.Writer)}
+	},
+}
+
+// getDumpBuffer returns an empty buffer with a freshly initialized tabwriter.
+func getDumpBuffer() *dumpBuffer {
+	buf := dumpBufferPool.Get().(*dumpBuffer)
+	buf.out.Reset()
+	buf.tw.Init(&buf.out, 0, 0, 1, ' ', 0)
+	return buf
+}
+
+// putDumpBuffer hands a buffer back to the pool unless it grew too large.
+func putDumpBuffer(buf *dumpBuffer) {
+	if buf.out.Cap() > maxPooledBufferSize {
+		return
+	}
+	dumpBufferPool.Put(buf)
+}
+
+// snapshotDumper renders stable, colorless output for golden-file tests.
+var snapshotDumper = NewDumper(WithoutColor(), WithoutHeader(), func(d *Dumper) *Dumper {
+	d.sortMapKeys = true
+	return d
+})
+
+// Snapshot returns a deterministic dump of v for golden-file and snapshot tests.
+// Output has no colors and no header, and map keys are sorted so repeated runs match byte for byte.
+// Struct fields keep their declaration order, which is already stable.
+// @group Testing
+//
+// Example: snapshot a map
+//
+//	v := map[string]int{"b": 2, "a": 1}
+//	out := godump.Snapshot(v)
+//	fmt.Println(out)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	//   b => 2 #int
+//	// }
+func Snapshot(v any) string {
+	return snapshotDumper.DumpStr(v)
+}
+
+// DumpJSONStr pretty-prints values as JSON and returns it as a string.
+// @group JSON
+//
+// Example: dump JSON string
+//
+//	v := map[string]int{"a": 1}
+//	d := godump.NewDumper()
+//	out := d.DumpJSONStr(v)
+//	_ = out
+//	// {"a":1}
+func (d *Dumper) DumpJSONStr(vs ...any) string {
+	if len(vs) == 0 {
+		return d.emptyJSON
+	}
+
+	var data any = vs
+	if len(vs) == 1 {
+		data = vs[0]
+	}
+
+	b, err := marshalJSON(data)
+	if err == nil {
+		b, err = d.indentJSON(b, "")
+	}
+	if err != nil {
+		//nolint:errchkjson // fallback handles this manually below
+		errorJSON, _ := json.Marshal(map[string]string{"error": err.Error()})
+		return string(errorJSON)
+	}
+	return string(b)
+}
+
+// DumpJSON prints a pretty-printed JSON string to the configured writer.
+// @group JSON
+//
+// Example: print JSON
+//
+//	v := map[string]int{"a": 1}
+//	d := godump.NewDumper()
+//	d.DumpJSON(v)
+//	// {
+//	//   "a": 1
+//	// }
+func (d *Dumper) DumpJSON(vs ...any) {
+	output := d.DumpJSONStr(vs...)
+	fmt.Fprintln(d.writer, output)
+	d.flush()
+}
+
+// DumpJSONStream writes values as pretty-printed JSON to w, encoding slice elements one at a time.
+// The output matches DumpJSON, but large slices are never held in memory as a single string.
+// @group JSON
+//
+// Example: stream JSON
+//
+//	v := []int{1, 2}
+//	d := godump.NewDumper()
+//	_ = d.DumpJSONStream(os.Stdout, v)
+//	// [
+//	//   1,
+//	//   2
+//	// ]
+func (d *Dumper) DumpJSONStream(w io.Writer, vs ...any) error {
+	if len(vs) == 0 {
+		_, err := fmt.Fprintln(w, d.emptyJSON)
+		return err
+	}
+
+	if len(vs) > 1 {
+		return d.streamJSONArray(w, reflect.ValueOf(vs))
+	}
+
+	rv := reflect.ValueOf(vs[0])
+	if isStreamableJSONArray(rv) {
+		return d.streamJSONArray(w, rv)
+	}
+
+	b, err := marshalJSON(vs[0])
+	if err == nil {
+		b, err = d.indentJSON(b, "")
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+// DumpJSONStream writes values as pretty-printed JSON to w, encoding slice elements one at a time.
+// @group JSON
+//
+// Example: stream JSON
+//
+//	v := []int{1, 2}
+//	_ = godump.DumpJSONStream(os.Stdout, v)
+//	// [
+//	//   1,
+//	//   2
+//	// ]
+func DumpJSONStream(w io.Writer, vs ...any) error {
+	return defaultDumper.DumpJSONStream(w, vs...)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isStreamableJSONArray reports whether rv encodes as a plain JSON array that can be written element by element.
+func isStreamableJSONArray(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return false
+		}
+	case reflect.Array:
+	default:
+		return false
+	}
+
+	t := rv.Type()
+	if t.Elem().Kind() == reflect.Uint8 {
+		return false // []byte encodes as a base64 string
+	}
+	for _, iface := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
+
+// streamJSONArray writes rv as an indented JSON array, encoding one element at a time.
+func (d *Dumper) streamJSONArray(w io.Writer, rv reflect.Value) error {
+	if rv.Len() == 0 {
+		_, err := io.WriteString(w, "[]\n")
+		return err
+	}
+
+	indent := d.indentString(1)
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		elem, err := marshalJSON(rv.Index(i).Interface())
+		if err == nil {
+			elem, err = d.indentJSON(elem, indent)
+		}
+		if err != nil {
+			return err
+		}
+		sep := ",\n" + indent
+		if i == 0 {
+			sep = "\n" + indent
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		if _, err := w.Write(elem); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+// DumpHTML dumps the values as HTML with colorized output.
+// @group HTML
+//
+// Example: dump HTML
+//
+//	v := map[string]int{"a": 1}
+//	html := godump.DumpHTML(v)
+//	_ = html
+//	// (html output)
+func DumpHTML(vs ...any) string {
+	return defaultDumper.DumpHTML(vs...)
+}
+
+// DumpHTML dumps the values as HTML with colorized output.
+// @group HTML
+//
+// Example: dump HTML with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := map[string]int{"a": 1}
+//	html := d.DumpHTML(v)
+//	_ = html
+//	fmt.Println(html)
+//	// (html output)
+func (d *Dumper) DumpHTML(vs ...any) string {
+	var sb strings.Builder
+	sb.WriteString(`<div style='background-color:black;'><pre style="background-color:black; color:white; padding:5px; border-radius: 5px">` + "\n")
+
+	htmlDumper := d.clone()
+	htmlDumper.htmlOutput = true
+	if !htmlDumper.disableColor {
+		htmlDumper.colorizer = colorizeHTML // use HTML colorizer
+	}
+
+	sb.WriteString(htmlDumper.DumpStr(vs...))
+
+	sb.WriteString("</pre></div>")
+	return sb.String()
+}
+
+// DumpJSON dumps the values as a pretty-printed JSON string.
+// If there is more than one value, they are dumped as a JSON array.
+// It returns an error string if marshaling fails.
+// @group JSON
+//
+// Example: print JSON
+//
+//	v := map[string]int{"a": 1}
+//	godump.DumpJSON(v)
+//	// {
+//	//   "a": 1
+//	// }
+func DumpJSON(vs ...any) {
+	defaultDumper.DumpJSON(vs...)
+}
+
+// DumpJSONStr dumps the values as a JSON string.
+// @group JSON
+//
+// Example: JSON string
+//
+//	v := map[string]int{"a": 1}
+//	out := godump.DumpJSONStr(v)
+//	_ = out
+//	// {"a":1}
+func DumpJSONStr(vs ...any) string {
+	return defaultDumper.DumpJSONStr(vs...)
+}
+
+// Dd is a debug function that prints the values and exits the program.
+// @group Dump
+//
+// Example: dump and exit
+//
+//	v := map[string]int{"a": 1}
+//	godump.Dd(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func Dd(vs ...any) {
+	defaultDumper.Dd(vs...)
+}
+
+// Dd is a debug function that prints the values and exits the program.
+// @group Debug
+//
+// Example: dump and exit with a custom dumper
+//
+//	d := godump.NewDumper()
+//	v := map[string]int{"a": 1}
+//	d.Dd(v)
+//	// #map[string]int {
+//	//   a => 1 #int
+//	// }
+func (d *Dumper) Dd(vs ...any) {
+	d.Dump(vs...)
+	exitFunc(1)
+}
+
+// Reset clears state the dumper caches between dumps while keeping its configuration.
+// It drops the color mode detected from NO_COLOR, FORCE_COLOR, TERM, and COLORTERM on
+// first use, so the next dump detects it again. Options such as writers, limits, themes,
+// redaction rules, and registered formatters are retained. Reference IDs, cycle tracking,
+// and field paths are scoped to each dump call and always restart at &1.
+// Reset must not be called concurrently with a dump on the same dumper.
+// @group Builder
+//
+// Example: pick up a changed environment
+//
+//	d := godump.NewDumper()
+//	d.Dump("before")
+//	os.Setenv("NO_COLOR", "1")
+//	d.Reset()
+//	d.Dump("after")
+//	// "after" #string
+func (d *Dumper) Reset() *Dumper {
+	if !d.disableColor {
+		d.colorizer = nil
+	}
+	return d
+}
+
+// RegisterFormatter renders values of type t with fn, shown with their type marker,
+// in place of the default rendering, including Stringer output and the built-in
+// formatters for types like time.Time. It shares the registry used by WithLazyFormatter.
+// The registry is copied on write, so other dumpers are never affected. Register
+// formatters before dumping; RegisterFormatter is not safe to call concurrently with a dump.
+// @group Builder
+//
+// Example: render a domain type
+//
+//	type UserID uint32
+//	d := godump.NewDumper()
+//	d.RegisterFormatter(reflect.TypeOf(UserID(0)), func(v reflect.Value) string {
+//		return fmt.Sprintf("user-%08x", v.Uint())
+//	})
+//	d.Dump(UserID(0xdeadbeef))
+//	// user-deadbeef #godump.UserID
+func (d *Dumper) RegisterFormatter(t reflect.Type, fn func(reflect.Value) string) {
+	WithLazyFormatter(t, fn)(d)
+}
+
+// clone creates a copy of the [Dumper] with the same configuration.
+// This is useful for creating a new dumper with the same settings without modifying the original.
+func (d *Dumper) clone() *Dumper {
+	newDumper := *d
+	return &newDumper
+}
+
+// colorize applies the configured [Colorizer] to the string with the given color code.
+// An empty code, as used by MonochromeTheme, leaves the string as is.
+func (d *Dumper) colorize(code, str string) string {
+	if code == "" {
+		return str
+	}
+	if d.colorizer == nil {
+		// this avoids detecting color if not needed
+		if d.disableColor {
+			d.colorizer = colorizeUnstyled
+			return d.colorizer(code, str)
+		}
+		d.colorizer = newColorizer(d.trueColor)
+	}
+	return d.colorizer(code, str)
+}
+
+// ensureColorizer initializes the colorizer when none is configured.
+func (d *Dumper) ensureColorizer() {
+	if d.colorizer == nil {
+		if d.disableColor {
+			d.colorizer = colorizeUnstyled
+			return
+		}
+		d.colorizer = newColorizer(d.trueColor)
+	}
+}
+
+// printDumpHeader prints the header for the dump output, including the file and line number.
+func (d *Dumper) printDumpHeader(out io.Writer) {
+	if d.disableHeader {
+		return
+	}
+	file, line := d.findFirstNonInternalFrame(d.skippedStackFrames)
+	if file == "" {
+		return
+	}
+	fmt.Fprintln(out, d.headerLine(file, line))
+}
+
+// headerLine renders the colored dump header for file and line, with file made
+// relative to the working directory when possible.
+func (d *Dumper) headerLine(file string, line int) string {
+	relPath := file
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			relPath = rel
+		}
+	}
+
+	header := fmt.Sprintf("<#dump // %s:%d", relPath, line)
+	if d.headerFormat != nil {
+		header = d.headerFormat(relPath, line)
+	}
+	return d.colorize(d.theme.MetaColor, header)
+}
+
+// findFirstNonInternalFrame iterates through the call stack to find the first non-internal frame.
+func (d *Dumper) findFirstNonInternalFrame(skip int) (string, int) {
+	for i := initialCallerSkip; i < defaultMaxStackDepth; i++ {
+		pc, file, line, ok := d.callerFn(i)
+		if !ok {
+			break
+		}
+		fn := runtime.FuncForPC(pc)
+		if fn == nil || !strings.Contains(fn.Name(), "godump") || strings.HasSuffix(file, "_test.go") {
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			return file, line
+		}
+	}
+	return "", 0
+}
+
+// hexOffset formats the offset column for byte i of a hex dump, shifted by WithHexDumpBaseOffset.
+func (d *Dumper) hexOffset(i int) string {
+	return fmt.Sprintf("%08x", d.hexBaseOffset+uint64(i))
+}
+
+// hexBytes renders the hex column of a hex dump line, padded to lineLen bytes, with an
+// extra space after every WithHexDumpGroupSize bytes except the last.
+func (d *Dumper) hexBytes(line []byte, lineLen int) string {
+	var sb strings.Builder
+	for j := 0; j < lineLen; j++ {
+		if j < len(line) {
+			fmt.Fprintf(&sb, "%02x ", line[j])
+		} else {
+			sb.WriteString("   ")
+		}
+		if d.hexGroupSize > 0 && j%d.hexGroupSize == d.hexGroupSize-1 && j < lineLen-1 {
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
+
+// formatByteSliceAsHexDump formats a byte slice as a hex dump with ASCII representation.
+func (d *Dumper) formatByteSliceAsHexDump(b []byte, indent int) string {
+	var sb strings.Builder
+
+	const lineLen = 16
+	const asciiMaxLen = 16
+	// The ASCII column starts one space after a full line of offset and hex columns.
+	asciiStartCol := len(d.hexOffset(0)) + 2 + len(d.hexBytes(make([]byte, lineLen), lineLen)) + 1
+
+	fieldIndent := d.indentString(indent)
+	bodyIndent := fieldIndent
+
+	// Header
+	sb.WriteString(fmt.Sprintf("([]uint8) (len=%d cap=%d) {\n", len(b), cap(b)))
+
+	for i := 0; i < len(b); i += lineLen {
+
+		end := i + lineLen
+		if end > len(b) {
+			end = len(b)
+		}
+		line := b[i:end]
+
+		visibleLen := 0
+
+		// Offset
+		offsetStr := d.hexOffset(i) + "  "
+		sb.WriteString(bodyIndent)
+		sb.WriteString(d.colorize(d.theme.KeyColor, offsetStr))
+		visibleLen += len(offsetStr)
+
+		// Hex bytes
+		hexStr := d.hexBytes(line, lineLen)
+		sb.WriteString(d.colorize(d.theme.NumberColor, hexStr))
+		visibleLen += len(hexStr)
+
+		// Padding before ASCII
+		padding := asciiStartCol - visibleLen
+		if padding < 1 {
+			padding = 1
+		}
+		sb.WriteString(strings.Repeat(" ", padding))
+
+		// ASCII section
+		sb.WriteString(d.colorize(d.theme.MetaColor, "| "))
+		asciiCount := 0
+		for _, c := range line {
+			ch := "."
+			if c >= 32 && c <= 126 {
+				ch = string(c)
+			}
+			sb.WriteString(d.colorize(d.theme.StringColor, ch))
+			asciiCount++
+		}
+		if asciiCount < asciiMaxLen {
+			sb.WriteString(strings.Repeat(" ", asciiMaxLen-asciiCount))
+		}
+		sb.WriteString(d.colorize(d.theme.MetaColor, " |") + "\n")
+	}
+
+	// Closing
+	fieldIndent = fieldIndent[:len(fieldIndent)-d.indentWidth]
+	sb.WriteString(fieldIndent + "}")
+	return sb.String()
+}
+
+func (d *Dumper) writeDump(w io.Writer, state *dumpState, vs ...any) {
+	w, done := d.startLimits(w, state)
+	defer done()
+
+	for _, v := range vs {
+		if state.out != nil && state.out.exceeded {
+			break
+		}
+		rv := reflect.ValueOf(v)
+		rv = makeAddressable(rv)
+		d.indentPrint(w, 0, "")
+		d.printValue(w, rv, 0, state)
+		fmt.Fprintln(w)
+	}
+}
+
+// startLimits arms the WithSafeLimits timeout and output cap on state. It returns the
+// writer to print through and a func to call once the dump is written, which stops the
+// timer and notes when output was cut off.
+func (d *Dumper) startLimits(w io.Writer, state *dumpState) (io.Writer, func()) {
+	cancel := func() {}
+	if d.timeout > 0 {
+		state.ctx, cancel = context.WithTimeout(context.Background(), d.timeout)
+	}
+	if d.maxOutputBytes > 0 {
+		state.out = &limitedWriter{w: w, remaining: d.maxOutputBytes}
+		w = state.out
+	}
+	return w, func() {
+		cancel()
+		if state.out != nil && state.out.exceeded {
+			fmt.Fprintln(state.out.w, d.colorize(d.theme.MetaColor, fmt.Sprintf("... (output truncated at %d bytes)", d.maxOutputBytes)))
+		}
+	}
+}
+
+// limitedWriter passes writes through until remaining bytes run out, then drops them.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+	exceeded  bool
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.exceeded {
+		return len(p), nil
+	}
+	if len(p) > lw.remaining {
+		lw.exceeded = true
+		return len(p), nil
+	}
+	lw.remaining -= len(p)
+	return lw.w.Write(p)
+}
+
+// limitHit reports whether a WithSafeLimits cap has already stopped the walk.
+func (d *Dumper) limitHit(state *dumpState) bool {
+	return (state.out != nil && state.out.exceeded) ||
+		(state.ctx != nil && state.ctx.Err() != nil) ||
+		(d.maxNodes > 0 && state.nodes >= d.maxNodes)
+}
+
+// walkLimit returns the note to print in place of the next value when a WithSafeLimits
+// cap has been hit, or "" to keep walking.
+func (d *Dumper) walkLimit(state *dumpState) string {
+	if state.out != nil && state.out.exceeded {
+		return "..."
+	}
+	if state.ctx != nil && state.ctx.Err() != nil {
+		return "... (timeout)"
+	}
+	if d.maxNodes > 0 {
+		if state.nodes >= d.maxNodes {
+			return "... (max nodes)"
+		}
+		state.nodes++
+	}
+	return ""
+}
+
+// typeColor returns the color for a type marker: the theme's TypeColor, or a per-package color with WithPackageColors.
+func (d *Dumper) typeColor(t reflect.Type) string {
+	if !d.packageColors {
+		return d.theme.TypeColor
+	}
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			t = t.Elem()
+			continue
+		}
+		break
+	}
+	pkg := t.PkgPath()
+	palette := d.theme.PackageColors
+	if pkg == "" || len(palette) == 0 {
+		return d.theme.TypeColor
+	}
+	h := fnv.New32a()
+	h.Write([]byte(pkg))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+func (d *Dumper) getTypeString(t reflect.Type) string {
+	if alias, ok := d.typeAliases[t]; ok {
+		return alias
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", d.getTypeString(t.Key()), d.getTypeString(t.Elem()))
+	case reflect.Slice:
+		return fmt.Sprintf("[]%s", d.getTypeString(t.Elem()))
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), d.getTypeString(t.Elem()))
+	case reflect.Ptr:
+		return fmt.Sprintf("*%s", d.getTypeString(t.Elem()))
+	default:
+		if d.maxTypeParamsLen > 0 && t.Name() != "" {
+			return trimTypeParams(t.String(), d.maxTypeParamsLen)
+		}
+		return t.String()
+	}
+}
+
+// trimTypeParams abbreviates the type argument list of a generic type name once it is
+// longer than limit, keeping leading arguments that fit, e.g. "pkg.Map[string, …]".
+// Only the bracket group closing the name is treated as the list, so brackets inside
+// the arguments, such as []int or map[K]V, are left intact.
+func trimTypeParams(name string, limit int) string {
+	if !strings.HasSuffix(name, "]") {
+		return name
+	}
+	open, depth := -1, 0
+	for i := len(name) - 1; i >= 0 && open < 0; i-- {
+		switch name[i] {
+		case ']':
+			depth++
+		case '[':
+			depth--
+			if depth == 0 {
+				open = i
+			}
+		}
+	}
+	// The list must directly follow the type's identifier, unlike the "[]" of a slice.
+	if open <= 0 || !isIdentByte(name[open-1]) {
+		return name
+	}
+	params := name[open+1 : len(name)-1]
+	if len(params) <= limit {
+		return name
+	}
+
+	var args []string
+	depth, start := 0, 0
+	for i, r := range params {
+		switch r {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, params[start:])
+
+	kept := make([]string, 0, len(args))
+	used := 0
+	for _, arg := range args {
+		if used+len(arg) > limit {
+			break
+		}
+		kept = append(kept, arg)
+		used += len(arg) + 2
+	}
+	return name[:open+1] + strings.Join(append(kept, "…"), ", ") + "]"
+}
+
+// isIdentByte reports whether c can end a Go identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func (d *Dumper) printValue(w io.Writer, v reflect.Value, indent int, state *dumpState) {
+	if !v.IsValid() {
+		fmt.Fprint(w, d.colorize(d.theme.NullColor, "<invalid>"))
+		return
+	}
+
+	if note := d.walkLimit(state); note != "" {
+		fmt.Fprint(w, d.colorize(d.theme.MetaColor, note))
+		return
+	}
+
+	if d.renderFunc != nil {
+		if s, ok := d.renderFunc(v, indent); ok {
+			fmt.Fprint(w, s)
+			return
+		}
+	}
+
+	if isNil(v) {
+		if d.nilFormatter != nil {
+			fmt.Fprint(w, d.colorize(d.theme.NullColor, d.nilFormatter(v.Type())))
+			return
+		}
+		typeStr := d.getTypeString(v.Type())
+		fmt.Fprintf(w, d.colorize(d.theme.StringColor, typeStr)+d.colorize(d.theme.NullColor, "(nil)"))
+		if d.interfaceMethodSets && v.Kind() == reflect.Interface && v.Type().NumMethod() > 0 {
+			fmt.Fprint(w, " "+d.colorize(d.theme.MetaColor, interfaceMethodNames(v.Type())))
+		}
+		return
+	}
+
+	if shouldTruncateAtDepth(v, indent, d.maxDepth+state.extraDepth) {
+		extra := 0
+		if d.onRecursionLimit != nil {
+			extra = d.onRecursionLimit(state.currentPath(), v.Type())
+		}
+		if extra <= 0 {
+			d.reportTruncation(state, "depth", indent)
+			fmt.Fprint(w, d.colorize(d.theme.MetaColor, "... (max depth)"))
+			return
+		}
+		state.extraDepth += extra
+		defer func() { state.extraDepth -= extra }()
+	}
+
+	if d.maxPathDepth > 0 && state.fieldDepth >= d.maxPathDepth {
+		if kind, ok := complexBaseKind(v); ok && kind == reflect.Struct {
+			fmt.Fprint(w, d.colorize(d.theme.MetaColor, "... (max path depth)"))
+			return
+		}
+	}
+
+	if d.htmlOutput && d.htmlDataAttrs && v.Kind() != reflect.Interface {
+		fmt.Fprintf(w, `<span data-type="%s" data-path="%s">`,
+			html.EscapeString(d.getTypeString(v.Type())), html.EscapeString(state.currentPath()))
+		defer fmt.Fprint(w, "</span>")
+	}
+
+	if !d.rawMode {
+		if fn, ok := d.lazyFormatters[v.Type()]; ok {
+			// Unexported fields are passed readable so fn can call Interface.
+			fmt.Fprint(w, d.colorize(d.theme.StringColor, d.obfuscateText(fn(forceExported(v))))+d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type())))
+			return
+		}
+
+		if s, ok := d.formatKnownType(v); ok {
+			fmt.Fprint(w, s)
+			return
+		}
+
+		if d.printFS(w, v, indent) {
+			return
+		}
+
+		if d.printFlagSet(w, v, indent) {
+			return
+		}
+
+		if d.printContainer(w, v, indent, state) {
+			return
+		}
+
+		if d.printOrderedMap(w, v, indent, state) {
+			return
+		}
+
+		if d.printRangeMap(w, v, indent, state) {
+			return
+		}
+
+		if s := d.asJSONMarshaler(v, indent); s != "" {
+			fmt.Fprint(w, s)
+			return
+		}
+
+		if s := d.asError(v); s != "" {
+			fmt.Fprint(w, s)
+			return
+		}
+
+		if s := d.asStringer(v); s != "" {
+			fmt.Fprint(w, s)
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Chan:
+		if d.drainChannels && d.printDrainedChan(w, v, indent, state) {
+			return
+		}
+		fmt.Fprintf(w, "%s(%s)", d.colorize(d.theme.TypeColor, d.getTypeString(v.Type())), d.colorize(d.theme.NumberColor, fmt.Sprintf("%#x", pointerOf(v))))
+		return
+	}
+
+	if d.zeroPointersAsNil && v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().IsZero() {
+		fmt.Fprint(w, d.colorize(d.theme.NullColor, "*"+d.getTypeString(v.Type().Elem())+"(zero)"))
+		return
+	}
+
+	if v.Kind() == reflect.Ptr && (v.CanAddr() || d.pointerIDs) {
+		if key, ok := trackableRef(v); ok {
+			if id, seen := state.refs[key]; seen {
+				d.printRef(w, state, id)
+				return
+			}
+			id := d.newRef(state, key)
+			if d.pointerIDs {
+				fmt.Fprint(w, d.colorize(d.theme.RefColor, fmt.Sprintf("&%d", id))+" ")
+			}
+		}
+	}
+
+	// We don't need to check any previous checks (validity, channel, nil,
+	// addressable pointer) since they all work directly on the pointer type. We
+	// can simply continue with the reference value from here and add a pointer
+	// prefix to the output.
+	ptrPrefix := ""
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			// A nil pointer further down a chain like **T; render it as its own nil value.
+			d.printValue(w, v, indent, state)
+			return
+		}
+		ptrPrefix += "*"
+		v = v.Elem()
+	}
+	if d.markPointers && ptrPrefix != "" {
+		fmt.Fprint(w, d.colorize(d.theme.RefColor, ptrPrefix))
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			// A pointer to a nil interface; keep its static type rather than <invalid>.
+			d.printValue(w, v, indent, state)
+			break
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr && !d.pointerIDs {
+			// Pointers held in interfaces aren't addressable, so they are tracked here;
+			// otherwise a cycle like x = &x would recurse without ever nesting deeper.
+			if key, ok := trackableRef(elem); ok {
+				if id, seen := state.refs[key]; seen {
+					d.printRef(w, state, id)
+					break
+				}
+				d.newRef(state, key)
+			}
+		}
+		if d.markPointers && ptrPrefix != "" {
+			// Name the interface layer between pointers, e.g. *(interface {}) *#*User.
+			fmt.Fprint(w, d.colorize(d.theme.RefColor, "("+d.getTypeString(v.Type())+") "))
+		}
+		d.printValue(w, elem, indent, state)
+	case reflect.Struct:
+		t := v.Type()
+		if d.collapseSingle && d.printCollapsedStruct(w, v, ptrPrefix) {
+			break
+		}
+		fields := d.visibleFields(t)
+		if d.onlyNonDefault {
+			fields = nonDefaultFields(v, fields)
+		}
+		fmt.Fprint(w, d.colorize(d.typeColor(v.Type()), fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+		if d.structFieldCount {
+			fmt.Fprint(w, d.colorize(d.theme.MetaColor, " "+pluralize(len(fields), "field")))
+		}
+		if len(fields) == 0 && !d.showMethods {
+			fmt.Fprint(w, " {}")
+			break
+		}
+		fmt.Fprintln(w, " {")
+
+		for _, i := range fields {
+			field := t.Field(i)
+			fieldVal := v.Field(i)
+
+			symbol := "+"
+			if field.PkgPath != "" {
+				symbol = "-"
+				fieldVal = forceExported(fieldVal)
+			}
+			d.indentPrint(w, indent+1, d.colorize(d.theme.SymbolColor, symbol)+field.Name)
+			fmt.Fprint(w, "	=> ")
+			if d.shouldRedactField(field.Name) {
+				fmt.Fprint(w, d.redactedValue(fieldVal))
+			} else if hasTagOption(field, "redact") {
+				fmt.Fprint(w, d.redactedTaggedValue(field, fieldVal))
+			} else if text, ok := d.taggedText(field, fieldVal); ok {
+				fmt.Fprint(w, text)
+			} else {
+				state.pushField(field.Name)
+				d.printValue(w, fieldVal, indent+1, state)
+				state.popPath()
+			}
+			fmt.Fprintln(w)
+		}
+		if d.showMethods {
+			mt := t
+			if ptrPrefix != "" {
+				mt = reflect.PointerTo(t)
+			}
+			d.printMethods(w, mt, indent+1)
+		}
+		d.indentPrint(w, indent, "")
+		fmt.Fprint(w, "}")
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprint(w, d.colorize(d.theme.NumberColor, d.obfuscateNumber(fmt.Sprintf("%v", v.Complex()))))
+	case reflect.UnsafePointer:
+		fmt.Fprint(w, d.colorize(d.theme.TypeColor, fmt.Sprintf("unsafe.Pointer(%#x)", pointerOf(v))))
+	case reflect.Map:
+		if isSet(v.Type()) {
+			d.printSet(w, v, ptrPrefix, state)
+			break
+		}
+
+		fmt.Fprintf(w, "%s {", d.colorize(d.typeColor(v.Type()), fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+		fmt.Fprintln(w)
+
+		limit := d.mapItemLimit()
+		if d.sampleItems > 0 {
+			limit = d.sampleItems
+		}
+		keys := d.mapKeys(v, limit, state)
+		// Keys are padded by hand rather than through the tabwriter so that each map
+		// aligns its own entries, even when nested blocks sit between them.
+		keyStrs := make([]string, 0, len(keys))
+		keyWidth := 0
+		for i, key := range keys {
+			if i >= limit {
+				break
+			}
+			keyStr := d.formatMapKey(key)
+			keyStrs = append(keyStrs, keyStr)
+			if n := utf8.RuneCountInString(keyStr); n > keyWidth {
+				keyWidth = n
+			}
+		}
+		for i, key := range keys {
+			if i >= limit {
+				break
+			}
+
+			keyStr := keyStrs[i]
+			pad := strings.Repeat(" ", keyWidth-utf8.RuneCountInString(keyStr))
+			d.indentPrint(w, indent+1, fmt.Sprintf(" %s%s => ", d.colorize(d.theme.KeyColor, keyStr), pad))
+			if key.Kind() == reflect.String && d.matchesRedactPattern(key.String()) {
+				fmt.Fprint(w, d.redactedValue(v.MapIndex(key)))
+			} else if !d.printMapValueRef(w, v.MapIndex(key), state) {
+				state.pushKey(keyStr)
+				d.printValue(w, v.MapIndex(key), indent+1, state)
+				state.popPath()
+			}
+			fmt.Fprintln(w)
+		}
+		if shown := len(keyStrs); shown < v.Len() {
+			d.reportTruncation(state, "map", v.Len())
+			d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, fmt.Sprintf("... (%d more truncated)", v.Len()-shown)))
+			fmt.Fprintln(w)
+		}
+		d.indentPrint(w, indent, "")
+		fmt.Fprint(w, "}")
+	case reflect.Slice, reflect.Array:
+		// []byte handling
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.CanConvert(reflect.TypeOf([]byte{})) { // Check if it can be converted to []byte
+				if data, ok := v.Convert(reflect.TypeOf([]byte{})).Interface().([]byte); ok {
+					hexDump := d.formatByteSliceAsHexDump(d.obfuscateBytes(data), indent+1)
+					fmt.Fprint(w, d.colorize(d.theme.StringColor, hexDump))
+					break
+				}
+			}
+		}
+
+		if d.matrixView && d.isMatrix(v) {
+			d.printMatrix(w, v, indent, ptrPrefix)
+			break
+		}
+
+		// Default rendering for other slices/arrays
+		fmt.Fprintf(w, "%s [", d.colorize(d.typeColor(v.Type()), fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+		fmt.Fprintln(w)
+
+		skipFrom, skipTo := d.sampleWindow(v.Len())
+		width := d.indexWidth(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if i == skipFrom {
+				d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, fmt.Sprintf("... (%d omitted)", skipTo-skipFrom)))
+				fmt.Fprintln(w)
+				i = skipTo - 1
+				continue
+			}
+			if d.sampleItems == 0 && i >= d.maxItems {
+				d.reportTruncation(state, "slice", v.Len())
+				d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, fmt.Sprintf("... (%d more truncated)", v.Len()-i))+"\n")
+				break
+			}
+			d.indentPrint(w, indent+1, fmt.Sprintf("%s => ", d.colorize(d.theme.NumberColor, fmt.Sprintf("%*d", width, i+d.indexBase))))
+			state.pushIndex(i)
+			d.printValue(w, v.Index(i), indent+1, state)
+			state.popPath()
+			fmt.Fprintln(w)
+		}
+		d.indentPrint(w, indent, "")
+		fmt.Fprint(w, "]")
+	case reflect.String:
+		str := escapeControl(d.obfuscateString(v.String()))
+		if n := utf8.RuneCountInString(str); n > d.maxStringLen {
+			d.reportTruncation(state, "string", n)
+			runes := []rune(str)
+			str = string(runes[:d.maxStringLen]) + "…"
+		}
+		fmt.Fprint(w, d.colorize(d.theme.SymbolColor, `"`)+d.colorize(d.valueColor(v, d.theme.StringColor), str)+d.colorize(d.theme.SymbolColor, `"`))
+	case reflect.Bool:
+		if v.Bool() {
+			fmt.Fprint(w, d.colorize(d.valueColor(v, d.theme.SymbolColor), d.boolString(true)))
+		} else {
+			fmt.Fprint(w, d.colorize(d.valueColor(v, d.theme.MetaColor), d.boolString(false)))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprint(w, d.colorize(d.valueColor(v, d.theme.NumberColor), d.obfuscateNumber(d.groupDigits(fmt.Sprint(v.Int())))))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprint(w, d.colorize(d.valueColor(v, d.theme.NumberColor), d.obfuscateNumber(d.groupDigits(fmt.Sprint(v.Uint())))))
+	case reflect.Float32, reflect.Float64:
+		if token := specialFloat(v.Float()); token != "" {
+			fmt.Fprint(w, d.colorize(d.theme.SymbolColor, token))
+		} else {
+			fmt.Fprint(w, d.colorize(d.valueColor(v, d.theme.NumberColor), d.obfuscateNumber(d.formatFloat(v.Float()))))
+		}
+	case reflect.Func:
+		if name, bound := methodName(v); name != "" {
+			fmt.Fprint(w, d.colorize(d.theme.KeyColor, name)+" ")
+			if bound {
+				fmt.Fprint(w, d.colorize(d.theme.MetaColor, "bound "))
+			}
+		}
+		fmt.Fprint(w, d.colorize(d.theme.TypeColor, v.Type().String()))
+		if loc := funcLocation(v); loc != "" {
+			fmt.Fprint(w, d.colorize(d.theme.RefColor, " @ "+loc))
+		}
+	default:
+		fmt.Fprint(w, d.colorize(d.theme.MetaColor, fallbackString(v)))
+	}
+
+	if !v.IsValid() {
+		return
+	}
+
+	// These types should not have post types since they have a body and already
+	// had their type written out.
+	if contains([]reflect.Kind{
+		reflect.Struct,
+		reflect.UnsafePointer,
+		reflect.Map,
+		reflect.Slice,
+		reflect.Array,
+		reflect.Ptr,
+		reflect.Interface,
+	}, v.Kind()) {
+		return
+	}
+
+	fmt.Fprint(w, d.colorize(d.typeColor(v.Type()), fmt.Sprintf(" #%s%s", ptrPrefix, d.getTypeString(v.Type()))))
 }
 
-// colorize applies the configured [Colorizer] to the string with the given color code.
-func (d *Dumper) colorize(code, str string) string {
-	if d.colorizer == nil {
-		// this avoids detecting color if not needed
-		if d.disableColor {
-			d.colorizer = colorizeUnstyled
-			return d.colorizer(code, str)
+// mapKeys returns the keys of map v in print order. Under the WithSafeLimits node cap or
+// timeout a large map is not read in full: only the first limit keys are collected, or
+// up to maxNodes of them when they must be sorted, and collection stops at the deadline.
+func (d *Dumper) mapKeys(v reflect.Value, limit int, state *dumpState) []reflect.Value {
+	sorted := d.sortMapKeys || d.mapValueDedup
+	if d.maxNodes <= 0 && state.ctx == nil {
+		keys := v.MapKeys()
+		if sorted {
+			d.sortKeys(keys)
 		}
-		d.colorizer = newColorizer()
+		return keys
 	}
-	return d.colorizer(code, str)
-}
 
-// ensureColorizer initializes the colorizer when none is configured.
-func (d *Dumper) ensureColorizer() {
-	if d.colorizer == nil {
-		if d.disableColor {
-			d.colorizer = colorizeUnstyled
-			return
+	bound := v.Len()
+	if !sorted && limit < bound {
+		bound = limit
+	} else if sorted && d.maxNodes > 0 && d.maxNodes < bound {
+		bound = d.maxNodes
+	}
+	keys := make([]reflect.Value, 0, bound)
+	iter := v.MapRange()
+	for len(keys) < bound && iter.Next() {
+		if len(keys)%1024 == 0 && state.ctx != nil && state.ctx.Err() != nil {
+			break
 		}
-		d.colorizer = newColorizer()
+		keys = append(keys, iter.Key())
 	}
+	if sorted {
+		d.sortKeys(keys)
+	}
+	return keys
 }
 
-// printDumpHeader prints the header for the dump output, including the file and line number.
-func (d *Dumper) printDumpHeader(out io.Writer) {
-	if d.disableHeader {
-		return
+// sortKeys orders map keys numerically, lexically, or by their rendered form for other key kinds.
+func (d *Dumper) sortKeys(keys []reflect.Value) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			}
+		}
+		return d.formatMapKey(a) < d.formatMapKey(b)
+	})
+}
+
+// formatMapKey renders a map key on a single line, expanding struct keys field by field.
+func (d *Dumper) formatMapKey(key reflect.Value) string {
+	if !key.CanInterface() {
+		return "<unexported>"
 	}
-	file, line := d.findFirstNonInternalFrame(d.skippedStackFrames)
-	if file == "" {
-		return
+	val := key.Interface()
+	if _, ok := val.(fmt.Stringer); (!ok || d.rawMode) && key.Kind() == reflect.Struct {
+		return d.inlineValue(key)
 	}
-
-	relPath := file
-	if wd, err := os.Getwd(); err == nil {
-		if rel, err := filepath.Rel(wd, file); err == nil {
-			relPath = rel
-		}
+	if d.quoteMapKeys && key.Kind() == reflect.String {
+		return `"` + escapeControl(key.String()) + `"`
 	}
+	return fmt.Sprintf("%v", val)
+}
 
-	header := fmt.Sprintf("<#dump // %s:%d", relPath, line)
-	fmt.Fprintln(out, d.colorize(colorGray, header))
+// inlineValue renders a value in compact single-line form, e.g. Point{X:1, Y:2}.
+// It reads fields through kind accessors so unexported fields are shown too.
+func (d *Dumper) inlineValue(v reflect.Value) string {
+	return d.inlineValueSeen(v, map[uintptr]bool{})
 }
 
-// findFirstNonInternalFrame iterates through the call stack to find the first non-internal frame.
-func (d *Dumper) findFirstNonInternalFrame(skip int) (string, int) {
-	for i := initialCallerSkip; i < defaultMaxStackDepth; i++ {
-		pc, file, line, ok := d.callerFn(i)
-		if !ok {
-			break
+// inlineValueSeen implements inlineValue. seen holds the pointers on the current path,
+// so a pointer cycle renders as &T{…} instead of recursing forever.
+func (d *Dumper) inlineValueSeen(v reflect.Value, seen map[uintptr]bool) string {
+	if !v.IsValid() {
+		return "<invalid>"
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "nil"
 		}
-		fn := runtime.FuncForPC(pc)
-		if fn == nil || !strings.Contains(fn.Name(), "godump") || strings.HasSuffix(file, "_test.go") {
-			if skip > 0 {
-				skip--
+		if v.Kind() == reflect.Ptr {
+			ptr := v.Pointer()
+			if seen[ptr] {
+				return "&" + d.getTypeString(v.Type().Elem()) + "{…}"
+			}
+			seen[ptr] = true
+			defer delete(seen, ptr)
+			return "&" + d.inlineValueSeen(v.Elem(), seen)
+		}
+		return d.inlineValueSeen(v.Elem(), seen)
+	case reflect.Struct:
+		t := v.Type()
+		name := t.Name()
+		if name == "" {
+			name = d.getTypeString(t)
+		}
+		fields := d.visibleFields(t)
+		parts := make([]string, 0, len(fields))
+		for _, i := range fields {
+			field := t.Field(i)
+			if hasTagOption(field, "redact") || d.shouldRedactField(field.Name) {
+				parts = append(parts, field.Name+":<redacted>")
 				continue
 			}
-
-			return file, line
+			parts = append(parts, field.Name+":"+d.inlineValueSeen(v.Field(i), seen))
+		}
+		return name + "{" + strings.Join(parts, ", ") + "}"
+	case reflect.Array:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, d.inlineValueSeen(v.Index(i), seen))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case reflect.String:
+		return `"` + escapeControl(d.obfuscateString(v.String())) + `"`
+	case reflect.Bool:
+		return d.boolString(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return d.obfuscateNumber(d.groupDigits(fmt.Sprint(v.Int())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return d.obfuscateNumber(d.groupDigits(fmt.Sprint(v.Uint())))
+	case reflect.Float32, reflect.Float64:
+		if token := specialFloat(v.Float()); token != "" {
+			return token
 		}
+		return d.obfuscateNumber(d.formatFloat(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		return d.obfuscateNumber(fmt.Sprintf("%v", v.Complex()))
+	default:
+		return d.getTypeString(v.Type())
 	}
-	return "", 0
 }
 
-// formatByteSliceAsHexDump formats a byte slice as a hex dump with ASCII representation.
-func (d *Dumper) formatByteSliceAsHexDump(b []byte, indent int) string {
-	// ===== return string directly =====
-	return string(b)
+// printDrainedChan receives and prints the items buffered in channel v for WithDrainChannels.
+// It returns false, leaving v untouched, when v is empty or cannot be received from.
+func (d *Dumper) printDrainedChan(w io.Writer, v reflect.Value, indent int, state *dumpState) bool {
+	if v.Type().ChanDir()&reflect.RecvDir == 0 || v.Len() == 0 {
+		return false
+	}
+	ch := forceExported(v)
+	if !ch.CanInterface() {
+		return false
+	}
+	n := v.Len()
+	if n > d.maxItems {
+		n = d.maxItems
+	}
 
-	var sb strings.Builder
+	fmt.Fprintf(w, "%s(%s) [", d.colorize(d.theme.TypeColor, d.getTypeString(v.Type())), d.colorize(d.theme.NumberColor, fmt.Sprintf("%#x", pointerOf(v))))
+	fmt.Fprintln(w)
+	width := d.indexWidth(n)
+	drained := 0
+	for ; drained < n; drained++ {
+		item, ok := ch.TryRecv()
+		if !ok {
+			break
+		}
+		d.indentPrint(w, indent+1, fmt.Sprintf("%s => ", d.colorize(d.theme.NumberColor, fmt.Sprintf("%*d", width, drained+d.indexBase))))
+		state.pushIndex(drained)
+		d.printValue(w, item, indent+1, state)
+		state.popPath()
+		fmt.Fprintln(w)
+	}
+	if left := v.Len(); left > 0 {
+		d.reportTruncation(state, "slice", drained+left)
+		d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, fmt.Sprintf("... (%d left in channel)", left)))
+		fmt.Fprintln(w)
+	}
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "] "+d.colorize(d.theme.MetaColor, fmt.Sprintf("(%d drained)", drained)))
+	return true
+}
 
-	const lineLen = 16
-	const asciiStartCol = 50
-	const asciiMaxLen = 16
+// printMapValueRef prints a ↩︎ reference when WithComparableKeyDedup is enabled and a
+// pointer map value was already dumped, and otherwise records it. It reports whether it printed.
+// Pointers held in interface values are left to printValue, which tracks them itself.
+func (d *Dumper) printMapValueRef(w io.Writer, v reflect.Value, state *dumpState) bool {
+	if !d.mapValueDedup || d.pointerIDs {
+		return false
+	}
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
 
-	fieldIndent := strings.Repeat(" ", indent*indentWidth)
-	bodyIndent := fieldIndent
+	key, ok := trackableRef(v)
+	if !ok {
+		return false
+	}
+	if id, ok := state.refs[key]; ok {
+		d.printRef(w, state, id)
+		return true
+	}
+	d.newRef(state, key)
+	return false
+}
 
-	// Header
-	sb.WriteString(fmt.Sprintf("([]uint8) (len=%d cap=%d) {\n", len(b), cap(b)))
+// newRef assigns the next reference id to key, remembering where it was first seen
+// when WithCycleFullPath is enabled.
+func (d *Dumper) newRef(state *dumpState, key refKey) int {
+	id := state.nextRefID
+	state.refs[key] = id
+	state.nextRefID++
+	if d.cycleFullPath {
+		if state.refPaths == nil {
+			state.refPaths = map[int]string{}
+		}
+		state.refPaths[id] = state.currentPath()
+	}
+	return id
+}
 
-	for i := 0; i < len(b); i += lineLen {
+// printRef prints a ↩︎ reference to id, followed by its origin path with WithCycleFullPath.
+func (d *Dumper) printRef(w io.Writer, state *dumpState, id int) {
+	ref := fmt.Sprintf("↩︎ &%d", id)
+	if path, ok := state.refPaths[id]; ok {
+		ref += " (" + path + ")"
+	}
+	fmt.Fprint(w, d.colorize(d.theme.RefColor, ref))
+}
 
-		end := i + lineLen
-		if end > len(b) {
-			end = len(b)
-		}
-		line := b[i:end]
+// mapItemLimit returns the per-map entry budget: WithMaxMapItems when set, else maxItems.
+func (d *Dumper) mapItemLimit() int {
+	if d.maxMapItems > 0 {
+		return d.maxMapItems
+	}
+	return d.maxItems
+}
 
-		visibleLen := 0
+// isSet reports whether t is a map used as a set, i.e. one whose values are empty structs.
+func isSet(t reflect.Type) bool {
+	elem := t.Elem()
+	return elem.Kind() == reflect.Struct && elem.NumField() == 0
+}
 
-		// Offset
-		offsetStr := fmt.Sprintf("%08x  ", i)
-		sb.WriteString(bodyIndent)
-		sb.WriteString(d.colorize(colorMeta, offsetStr))
-		visibleLen += len(offsetStr)
+// printSet renders a map[T]struct{} inline as its keys, e.g. {a, b, c}, sorted unless
+// WithSortedMapKeys(false) is set.
+func (d *Dumper) printSet(w io.Writer, v reflect.Value, ptrPrefix string, state *dumpState) {
+	keys := d.mapKeys(v, d.mapItemLimit(), state)
 
-		// Hex bytes
-		for j := 0; j < lineLen; j++ {
-			var hexStr string
-			if j < len(line) {
-				hexStr = fmt.Sprintf("%02x ", line[j])
-			} else {
-				hexStr = "   "
-			}
-			if j == 7 {
-				hexStr += " "
-			}
-			sb.WriteString(d.colorize(colorCyan, hexStr))
-			visibleLen += len(hexStr)
+	parts := make([]string, 0, len(keys))
+	for i, key := range keys {
+		if i >= d.mapItemLimit() {
+			break
 		}
+		parts = append(parts, d.colorize(d.theme.KeyColor, d.formatMapKey(key)))
+	}
+	if len(parts) < v.Len() {
+		d.reportTruncation(state, "map", v.Len())
+		parts = append(parts, d.colorize(d.theme.MetaColor, "... (truncated)"))
+	}
+	fmt.Fprint(w, d.colorize(d.typeColor(v.Type()), fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+	fmt.Fprint(w, " {"+strings.Join(parts, ", ")+"}")
+}
 
-		// Padding before ASCII
-		padding := asciiStartCol - visibleLen
-		if padding < 1 {
-			padding = 1
-		}
-		sb.WriteString(strings.Repeat(" ", padding))
+// boolString returns the display text for b, honoring WithCustomBoolStrings.
+func (d *Dumper) boolString(b bool) string {
+	if b && d.trueString != "" {
+		return d.trueString
+	}
+	if !b && d.falseString != "" {
+		return d.falseString
+	}
+	return strconv.FormatBool(b)
+}
 
-		// ASCII section
-		sb.WriteString(d.colorize(colorGray, "| "))
-		asciiCount := 0
-		for _, c := range line {
-			ch := "."
-			if c >= 32 && c <= 126 {
-				ch = string(c)
-			}
-			sb.WriteString(d.colorize(colorLime, ch))
-			asciiCount++
-		}
-		if asciiCount < asciiMaxLen {
-			sb.WriteString(strings.Repeat(" ", asciiMaxLen-asciiCount))
-		}
-		sb.WriteString(d.colorize(colorGray, " |") + "\n")
+// reportTruncation notifies the WithCallbackOnTruncate hook, if any.
+func (d *Dumper) reportTruncation(state *dumpState, kind string, total int) {
+	if d.onTruncate != nil {
+		d.onTruncate(kind, state.currentPath(), total)
 	}
+}
 
-	// Closing
-	fieldIndent = fieldIndent[:len(fieldIndent)-indentWidth]
-	sb.WriteString(fieldIndent + "}")
-	return sb.String()
+// indexWidth returns the column width for the indices of an n-element slice or array,
+// based on the highest index that will actually be printed.
+func (d *Dumper) indexWidth(n int) int {
+	if d.arrayIndexWidth > 0 {
+		return d.arrayIndexWidth
+	}
+	last := n - 1
+	if d.sampleItems == 0 && last >= d.maxItems {
+		last = d.maxItems - 1
+	}
+	if last < 0 {
+		return 1
+	}
+	return len(strconv.Itoa(last + d.indexBase))
 }
 
-func (d *Dumper) writeDump(w io.Writer, state *dumpState, vs ...any) {
-	for _, v := range vs {
-		rv := reflect.ValueOf(v)
-		rv = makeAddressable(rv)
-		d.printValue(w, rv, 0, state)
-		fmt.Fprintln(w)
+// sampleWindow returns the half-open range of indexes hidden by WithSampleLargeCollections,
+// or -1, -1 when every element is shown.
+func (d *Dumper) sampleWindow(n int) (int, int) {
+	if d.sampleItems <= 0 || n <= d.sampleItems {
+		return -1, -1
 	}
+	head := (d.sampleItems + 1) / 2
+	tail := d.sampleItems - head
+	return head, n - tail
 }
 
-func (d *Dumper) getTypeString(t reflect.Type) string {
-	switch t.Kind() {
-	case reflect.Map:
-		return fmt.Sprintf("map[%s]%s", d.getTypeString(t.Key()), d.getTypeString(t.Elem()))
-	case reflect.Slice:
-		return fmt.Sprintf("[]%s", d.getTypeString(t.Elem()))
-	case reflect.Array:
-		return fmt.Sprintf("[%d]%s", t.Len(), d.getTypeString(t.Elem()))
-	case reflect.Ptr:
-		return fmt.Sprintf("*%s", d.getTypeString(t.Elem()))
+// isMatrix reports whether v is a non-empty, rectangular slice/array of scalar slices/arrays within maxItems.
+func (d *Dumper) isMatrix(v reflect.Value) bool {
+	rowType := v.Type().Elem()
+	if rowType.Kind() != reflect.Slice && rowType.Kind() != reflect.Array {
+		return false
+	}
+	switch rowType.Elem().Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
 	default:
-		return t.String()
+		return false
 	}
-}
 
-func (d *Dumper) printValue(w io.Writer, v reflect.Value, indent int, state *dumpState) {
-	if !v.IsValid() {
-		fmt.Fprint(w, d.colorize(colorGray, "<invalid>"))
-		return
+	if v.Len() == 0 || v.Len() > d.maxItems {
+		return false
+	}
+	cols := v.Index(0).Len()
+	if cols == 0 || cols > d.maxItems {
+		return false
+	}
+	for i := 1; i < v.Len(); i++ {
+		if v.Index(i).Len() != cols {
+			return false
+		}
 	}
+	return true
+}
 
-	if isNil(v) {
-		typeStr := d.getTypeString(v.Type())
-		fmt.Fprintf(w, d.colorize(colorLime, typeStr)+d.colorize(colorGray, "(nil)"))
-		return
+// printMatrix renders a matrix as rows of right-justified cells.
+func (d *Dumper) printMatrix(w io.Writer, v reflect.Value, indent int, ptrPrefix string) {
+	rows, cols := v.Len(), v.Index(0).Len()
+	cells := make([][]string, rows)
+	widths := make([]int, cols)
+	for i := 0; i < rows; i++ {
+		cells[i] = make([]string, cols)
+		for j := 0; j < cols; j++ {
+			cell := d.inlineValue(v.Index(i).Index(j))
+			cells[i][j] = cell
+			if n := utf8.RuneCountInString(cell); n > widths[j] {
+				widths[j] = n
+			}
+		}
 	}
 
-	if shouldTruncateAtDepth(v, indent, d.maxDepth) {
-		fmt.Fprint(w, d.colorize(colorGray, "... (max depth)"))
-		return
+	fmt.Fprintf(w, "%s [", d.colorize(d.typeColor(v.Type()), fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+	fmt.Fprintln(w)
+	for _, row := range cells {
+		parts := make([]string, cols)
+		for j, cell := range row {
+			pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+			parts[j] = pad + d.colorize(d.theme.NumberColor, cell)
+		}
+		d.indentPrint(w, indent+1, "["+strings.Join(parts, " ")+"]")
+		fmt.Fprintln(w)
 	}
+	d.indentPrint(w, indent, "")
+	fmt.Fprint(w, "]")
+}
 
-	if s := d.asStringer(v); s != "" {
-		fmt.Fprint(w, s)
-		return
+// fallbackString renders kinds printValue has no dedicated case for, so nothing is silently dropped.
+func fallbackString(v reflect.Value) string {
+	if v.IsValid() && v.CanInterface() {
+		return fmt.Sprintf("%v", v.Interface())
 	}
+	return fmt.Sprintf("<unrenderable kind: %s>", v.Kind())
+}
 
-	switch v.Kind() {
-	case reflect.Chan:
-		typ := d.colorizer(colorGray, d.getTypeString(v.Type()))
-		fmt.Fprintf(w, "%s(%s)", d.colorize(colorGray, typ), d.colorize(colorCyan, fmt.Sprintf("%#x", v.Pointer())))
-		return
+// methodName returns a readable name like (*pkg.Type).Method when a func value is a method value
+// or method expression, and reports whether it is bound to a receiver. It returns "" for other funcs.
+func methodName(v reflect.Value) (string, bool) {
+	fn := runtime.FuncForPC(pointerOf(v))
+	if fn == nil {
+		return "", false
 	}
 
-	if v.Kind() == reflect.Ptr && v.CanAddr() {
-		ptr := v.Pointer()
-		if id, ok := state.refs[ptr]; ok {
-			fmt.Fprintf(w, d.colorize(colorRef, "↩︎ &%d"), id)
-			return
-		} else {
-			state.refs[ptr] = state.nextRefID
-			state.nextRefID++
+	full := fn.Name()
+	short := full[strings.LastIndex(full, "/")+1:]
+	bound := strings.HasSuffix(short, "-fm")
+	short = strings.TrimSuffix(short, "-fm")
+
+	pkg, rest, ok := strings.Cut(short, ".")
+	if !ok {
+		return "", false
+	}
+	if strings.HasPrefix(rest, "(*") {
+		typ, method, ok := strings.Cut(strings.TrimPrefix(rest, "(*"), ").")
+		if !ok || strings.Contains(method, ".") {
+			return "", false
 		}
+		return "(*" + pkg + "." + typ + ")." + method, bound
 	}
 
-	// We don't need to check any previous checks (validity, channel, nil,
-	// addressable pointer) since they all work directly on the pointer type. We
-	// can simply continue with the reference value from here and add a pointer
-	// prefix to the output.
-	ptrPrefix := ""
-	for v.Kind() == reflect.Ptr {
-		ptrPrefix += "*"
-		v = v.Elem()
+	parts := strings.Split(rest, ".")
+	if len(parts) != 2 || parts[0] == "" || isClosureName(parts[1]) {
+		return "", false
 	}
+	return pkg + "." + rest, bound
+}
 
-	switch v.Kind() {
-	case reflect.Interface:
-		d.printValue(w, v.Elem(), indent, state)
-	case reflect.Struct:
-		t := v.Type()
-		fmt.Fprintf(w, "%s {", d.colorize(colorGray, fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
-		fmt.Fprintln(w)
+// isClosureName reports whether a symbol segment is a compiler-generated closure name such as func1.
+func isClosureName(s string) bool {
+	digits := strings.TrimPrefix(s, "func")
+	if digits == s || digits == "" {
+		return false
+	}
+	return strings.Trim(digits, "0123456789") == ""
+}
 
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			fieldVal := v.Field(i)
-			if !d.shouldIncludeField(field.Name) {
-				continue
-			}
+// funcLocation returns the file:line where a func value is defined, or "" when it can't be resolved.
+func funcLocation(v reflect.Value) string {
+	fn := runtime.FuncForPC(pointerOf(v))
+	if fn == nil {
+		return ""
+	}
+	file, line := fn.FileLine(fn.Entry())
+	if file == "" || strings.HasPrefix(file, "<") {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
 
-			symbol := "+"
-			if field.PkgPath != "" {
-				symbol = "-"
-				fieldVal = forceExported(fieldVal)
-			}
-			indentPrint(w, indent+1, d.colorize(colorYellow, symbol)+field.Name)
-			fmt.Fprint(w, "	=> ")
-			if d.shouldRedactField(field.Name) {
-				fmt.Fprint(w, d.redactedValue(fieldVal))
-			} else {
-				d.printValue(w, fieldVal, indent+1, state)
-			}
-			fmt.Fprintln(w)
-		}
-		indentPrint(w, indent, "")
-		fmt.Fprint(w, "}")
-	case reflect.Complex64, reflect.Complex128:
-		fmt.Fprint(w, d.colorize(colorCyan, fmt.Sprintf("%v", v.Complex())))
-	case reflect.UnsafePointer:
-		fmt.Fprint(w, d.colorize(colorGray, fmt.Sprintf("unsafe.Pointer(%#x)", v.Pointer())))
-	case reflect.Map:
-		fmt.Fprintf(w, "%s {", d.colorize(colorGray, fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
-		fmt.Fprintln(w)
+// asJSONMarshaler renders json.Marshaler values by their indented MarshalJSON output when
+// WithJSONMarshalerRendering is enabled. Marshal errors and panics fall back to the normal dump.
+func (d *Dumper) asJSONMarshaler(v reflect.Value, indent int) (out string) {
+	if !d.jsonMarshalers {
+		return ""
+	}
 
-		keys := v.MapKeys()
-		for i, key := range keys {
-			if i >= d.maxItems {
-				indentPrint(w, indent+1, d.colorize(colorGray, "... (truncated)"))
-				break
-			}
+	val := forceExported(v)
+	if !val.CanInterface() {
+		return ""
+	}
+	m, ok := val.Interface().(json.Marshaler)
+	if !ok && val.Kind() != reflect.Ptr && val.CanAddr() {
+		m, ok = val.Addr().Interface().(json.Marshaler)
+	}
+	if !ok {
+		return ""
+	}
 
-			var val any
-			if key.CanInterface() {
-				val = key.Interface()
-			} else {
-				val = "<unexported>"
-			}
-			keyStr := fmt.Sprintf("%v", val)
-			indentPrint(w, indent+1, fmt.Sprintf(" %s => ", d.colorize(colorMeta, keyStr)))
-			d.printValue(w, v.MapIndex(key), indent+1, state)
-			fmt.Fprintln(w)
-		}
-		indentPrint(w, indent, "")
-		fmt.Fprint(w, "}")
-	case reflect.Slice, reflect.Array:
-		// []byte handling
-		if v.Type().Elem().Kind() == reflect.Uint8 {
-			if v.CanConvert(reflect.TypeOf([]byte{})) { // Check if it can be converted to []byte
-				if data, ok := v.Convert(reflect.TypeOf([]byte{})).Interface().([]byte); ok {
-					hexDump := d.formatByteSliceAsHexDump(data, indent+1)
-					fmt.Fprint(w, d.colorize(colorLime, hexDump))
-					break
-				}
-			}
+	defer func() {
+		if recover() != nil {
+			out = ""
 		}
+	}()
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+	b, err = d.indentJSON(b, d.indentString(indent))
+	if err != nil {
+		return ""
+	}
 
-		// Default rendering for other slices/arrays
-		fmt.Fprintf(w, "%s [", d.colorize(colorGray, fmt.Sprintf("#%s%s", ptrPrefix, d.getTypeString(v.Type()))))
-		fmt.Fprintln(w)
+	typ := val.Type()
+	if val.Kind() == reflect.Interface && !val.IsNil() {
+		typ = val.Elem().Type()
+	}
+	return d.colorize(d.theme.StringColor, d.obfuscateText(string(b))) + d.colorize(d.typeColor(typ), " #"+d.getTypeString(typ))
+}
 
-		for i := 0; i < v.Len(); i++ {
-			if i >= d.maxItems {
-				indentPrint(w, indent+1, d.colorize(colorGray, "... (truncated)\n"))
-				break
-			}
-			indentPrint(w, indent+1, fmt.Sprintf("%s => ", d.colorize(colorCyan, fmt.Sprintf("%d", i))))
-			d.printValue(w, v.Index(i), indent+1, state)
-			fmt.Fprintln(w)
-		}
-		indentPrint(w, indent, "")
-		fmt.Fprint(w, "]")
-	case reflect.String:
-		str := escapeControl(v.String())
-		if utf8.RuneCountInString(str) > d.maxStringLen {
-			runes := []rune(str)
-			str = string(runes[:d.maxStringLen]) + "…"
-		}
-		fmt.Fprint(w, d.colorize(colorYellow, `"`)+d.colorize(colorLime, str)+d.colorize(colorYellow, `"`))
-	case reflect.Bool:
-		if v.Bool() {
-			fmt.Fprint(w, d.colorize(colorYellow, "true"))
-		} else {
-			fmt.Fprint(w, d.colorize(colorGray, "false"))
-		}
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		fmt.Fprint(w, d.colorize(colorCyan, fmt.Sprint(v.Int())))
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		fmt.Fprint(w, d.colorize(colorCyan, fmt.Sprint(v.Uint())))
-	case reflect.Float32, reflect.Float64:
-		fmt.Fprint(w, d.colorize(colorCyan, fmt.Sprintf("%f", v.Float())))
-	case reflect.Func:
-		fmt.Fprint(w, d.colorize(colorGray, v.Type().String()))
+// asError renders values implementing error by their message in red when WithSemanticColors is enabled.
+func (d *Dumper) asError(v reflect.Value) string {
+	if !d.semanticColors {
+		return ""
 	}
 
-	// These types should not have post types since they have a body and already
-	// had their type written out.
-	if contains([]reflect.Kind{
-		reflect.Struct,
-		reflect.UnsafePointer,
-		reflect.Map,
-		reflect.Slice,
-		reflect.Array,
-		reflect.Ptr,
-		reflect.Interface,
-	}, v.Kind()) {
-		return
+	val := forceExported(v)
+	if !val.CanInterface() {
+		return ""
+	}
+	err, ok := val.Interface().(error)
+	if !ok {
+		return ""
+	}
+	// A typed nil pointer would panic in most Error methods.
+	if rv := reflect.ValueOf(err); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return d.colorize(d.theme.NullColor, d.getTypeString(rv.Type())+"(nil)")
 	}
+	typ := val.Type()
+	if val.Kind() == reflect.Interface && !val.IsNil() {
+		typ = val.Elem().Type()
+	}
+	return d.colorize(d.theme.ErrorColor, d.obfuscateText(err.Error())) + d.colorize(d.typeColor(typ), " #"+d.getTypeString(typ))
+}
 
-	fmt.Fprint(w, d.colorizer(colorGray, fmt.Sprintf(" #%s%s", ptrPrefix, d.getTypeString(v.Type()))))
+// valueColor returns the color for a scalar value: def normally, or a meaning-based
+// color with WithSemanticColors (dimmed zero values, green true).
+func (d *Dumper) valueColor(v reflect.Value, def string) string {
+	if !d.semanticColors {
+		return def
+	}
+	if v.IsZero() {
+		return d.theme.ZeroColor
+	}
+	if v.Kind() == reflect.Bool {
+		return d.theme.BoolColor
+	}
+	return def
 }
 
 // asStringer checks if the value implements fmt.Stringer and returns its string representation.
@@ -1096,17 +4168,139 @@ func (d *Dumper) asStringer(v reflect.Value) string {
 		if s, ok := val.Interface().(fmt.Stringer); ok {
 			rv := reflect.ValueOf(s)
 			if rv.Kind() == reflect.Ptr && rv.IsNil() {
-				return d.colorize(colorGray, val.Type().String()+"(nil)")
+				return d.colorize(d.theme.NullColor, val.Type().String()+"(nil)")
 			}
-			return d.colorize(colorLime, s.String()) + d.colorize(colorGray, " #"+d.getTypeString(val.Type()))
+			return d.colorize(d.theme.StringColor, d.obfuscateText(s.String())) + d.colorize(d.typeColor(val.Type()), " #"+d.getTypeString(val.Type()))
 		}
 	}
 	return ""
 }
 
-// indentPrint prints indented text to the writer.
-func indentPrint(w io.Writer, indent int, text string) {
-	fmt.Fprint(w, strings.Repeat(" ", indent*indentWidth)+text)
+// formatFloat renders a finite float with fixed precision and digit grouping.
+func (d *Dumper) formatFloat(f float64) string {
+	return d.groupDigits(fmt.Sprintf("%f", f))
+}
+
+// specialFloat returns the NaN, +Inf, or -Inf token for non-finite floats, or "" for finite ones.
+func specialFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return ""
+	}
+}
+
+// obfuscateString replaces every character of s with "x" when WithObfuscateValues is enabled.
+func (d *Dumper) obfuscateString(s string) string {
+	if !d.obfuscateValues {
+		return s
+	}
+	return strings.Repeat("x", utf8.RuneCountInString(s))
+}
+
+// obfuscateBytes replaces every byte of b with 'x' when WithObfuscateValues is enabled, so
+// hex dumps keep their length and layout without revealing the data.
+func (d *Dumper) obfuscateBytes(b []byte) []byte {
+	if !d.obfuscateValues {
+		return b
+	}
+	return bytes.Repeat([]byte{'x'}, len(b))
+}
+
+// obfuscateNumber replaces the digits of a formatted number with "9" when WithObfuscateValues
+// is enabled, keeping its sign, separators, and exponent so it retains its magnitude.
+func (d *Dumper) obfuscateNumber(s string) string {
+	if !d.obfuscateValues {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return '9'
+		}
+		return r
+	}, s)
+}
+
+// obfuscateText masks free-form text such as Stringer output when WithObfuscateValues is
+// enabled: digits become "9", letters become "x", and punctuation is kept.
+func (d *Dumper) obfuscateText(s string) string {
+	if !d.obfuscateValues {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return '9'
+		case unicode.IsLetter(r):
+			return 'x'
+		}
+		return r
+	}, s)
+}
+
+// groupDigits inserts the configured thousands separator into the integer part of a formatted
+// number and swaps in the configured decimal separator.
+func (d *Dumper) groupDigits(num string) string {
+	if d.thousandsSep == 0 && d.decimalSep == 0 {
+		return num
+	}
+
+	sign := ""
+	if strings.HasPrefix(num, "-") || strings.HasPrefix(num, "+") {
+		sign, num = num[:1], num[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(num, ".")
+
+	var sb strings.Builder
+	for i, r := range intPart {
+		if d.thousandsSep != 0 && i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteRune(d.thousandsSep)
+		}
+		sb.WriteRune(r)
+	}
+	if hasFrac {
+		if d.decimalSep != 0 {
+			sb.WriteRune(d.decimalSep)
+		} else {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(frac)
+	}
+	return sign + sb.String()
+}
+
+// indentPrint prints indented text to the writer, prefixed by its depth with WithDepthNumbers.
+func (d *Dumper) indentPrint(w io.Writer, indent int, text string) {
+	if d.depthNumbers {
+		fmt.Fprint(w, d.colorize(d.theme.MetaColor, fmt.Sprintf("[%d] ", indent)))
+	}
+	if n := indent * d.indentWidth; n <= len(indentSpaceBytes) {
+		w.Write(indentSpaceBytes[:n])
+	} else {
+		io.WriteString(w, d.indentString(indent))
+	}
+	if text != "" {
+		io.WriteString(w, text)
+	}
+}
+
+// indentSpaces backs indentString; slicing it avoids allocating a new string per line.
+var indentSpaces = strings.Repeat(" ", 256)
+
+// indentSpaceBytes lets indentPrint write padding without converting indentSpaces per line.
+var indentSpaceBytes = []byte(indentSpaces)
+
+// indentString returns the leading whitespace for the given depth.
+func (d *Dumper) indentString(indent int) string {
+	if n := indent * d.indentWidth; n <= len(indentSpaces) {
+		return indentSpaces[:n]
+	}
+	return strings.Repeat(" ", indent*d.indentWidth)
 }
 
 // forceExported returns a value that is guaranteed to be exported, even if it is unexported.
@@ -1117,10 +4311,33 @@ func forceExported(v reflect.Value) reflect.Value {
 	if v.CanAddr() {
 		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
 	}
+	// Maps, channels, and pointers are a single pointer word, so an unaddressable copy
+	// can be rebuilt from that pointer; this keeps their keys and elements interfaceable.
+	switch v.Kind() {
+	case reflect.Map, reflect.Chan:
+		p := unsafe.Pointer(v.Pointer())
+		return reflect.NewAt(v.Type(), unsafe.Pointer(&p)).Elem()
+	case reflect.Ptr:
+		return reflect.NewAt(v.Type().Elem(), unsafe.Pointer(v.Pointer()))
+	}
 	// Final fallback: return original value, even if unexported
 	return v
 }
 
+// pointerOf returns v.Pointer() for the kinds that support it, and 0 for anything else,
+// including invalid values, where reflect would panic.
+func pointerOf(v reflect.Value) uintptr {
+	if !v.IsValid() {
+		return 0
+	}
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return v.Pointer()
+	default:
+		return 0
+	}
+}
+
 // makeAddressable ensures the value is addressable, wrapping structs in pointers if necessary.
 func makeAddressable(v reflect.Value) reflect.Value {
 	// Already addressable? Do nothing
@@ -1164,6 +4381,7 @@ func escapeControl(s string) string {
 }
 
 // detectColor checks environment variables to determine if color output should be enabled.
+// NO_COLOR and FORCE_COLOR take precedence over TERM=dumb.
 func detectColor() bool {
 	if os.Getenv("NO_COLOR") != "" {
 		return false
@@ -1171,15 +4389,32 @@ func detectColor() bool {
 	if os.Getenv("FORCE_COLOR") != "" {
 		return true
 	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
 	return true
 }
 
-// newColorizer picks the appropriate colorizer based on environment overrides.
-func newColorizer() Colorizer {
-	if detectColor() {
-		return colorizeANSI
+// detectTrueColor reports whether the terminal advertises 24-bit color support.
+func detectTrueColor() bool {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return true
+	}
+	return strings.HasSuffix(os.Getenv("TERM"), "-direct")
+}
+
+// newColorizer picks the appropriate colorizer based on environment overrides:
+// no color, the 256-color palette, or 24-bit color when the terminal supports it
+// or trueColor is set.
+func newColorizer(trueColor bool) Colorizer {
+	if !detectColor() {
+		return colorizeUnstyled
 	}
-	return colorizeUnstyled
+	if trueColor || detectTrueColor() {
+		return colorizeTrueColor
+	}
+	return colorizeANSI
 }
 
 // contains reports whether target exists in the candidates slice.
@@ -1193,8 +4428,222 @@ func contains(candidates []reflect.Kind, target reflect.Kind) bool {
 	return false
 }
 
-// shouldIncludeField returns true when the field survives include/exclude filtering (include takes precedence).
+// printCollapsedStruct renders a struct with one exported scalar field as #Type(value).
+// It returns false when the struct doesn't qualify and should render as a block.
+func (d *Dumper) printCollapsedStruct(w io.Writer, v reflect.Value, ptrPrefix string) bool {
+	t := v.Type()
+	fields := d.visibleFields(t)
+	if len(fields) != 1 {
+		return false
+	}
+	field := t.Field(fields[0])
+	fieldVal := v.Field(fields[0])
+	if field.PkgPath != "" || d.shouldRedactField(field.Name) || hasTagOption(field, "redact") ||
+		isComplexValue(fieldVal) || isNil(fieldVal) {
+		return false
+	}
+
+	color := d.theme.NumberColor
+	if fieldVal.Kind() == reflect.String {
+		color = d.theme.StringColor
+	}
+	fmt.Fprint(w, d.colorize(d.typeColor(t), fmt.Sprintf("#%s%s(", ptrPrefix, d.getTypeString(t))))
+	fmt.Fprint(w, d.colorize(color, d.inlineValue(fieldVal)))
+	fmt.Fprint(w, d.colorize(d.typeColor(t), ")"))
+	return true
+}
+
+// visibleFields returns the indexes of the struct fields that survive field filtering
+// and are not tagged `godump:"-"`.
+func (d *Dumper) visibleFields(t reflect.Type) []int {
+	hideProto := d.hideProtoInternals && isProtoMessage(t)
+	fields := make([]int, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if hideProto && isProtoInternalField(t.Field(i)) {
+			continue
+		}
+		if t.Field(i).Tag.Get("godump") == "-" {
+			continue
+		}
+		if d.shouldIncludeField(t.Field(i).Name) {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// printMethods lists the exported method set of t, bounded by maxItems.
+func (d *Dumper) printMethods(w io.Writer, t reflect.Type, indent int) {
+	if t.NumMethod() == 0 {
+		return
+	}
+	d.indentPrint(w, indent, d.colorize(d.theme.MetaColor, "methods:"))
+	fmt.Fprintln(w)
+	for i := 0; i < t.NumMethod(); i++ {
+		if i >= d.maxItems {
+			d.indentPrint(w, indent+1, d.colorize(d.theme.MetaColor, "... (truncated)"))
+			fmt.Fprintln(w)
+			break
+		}
+		m := t.Method(i)
+		d.indentPrint(w, indent+1, d.colorize(d.theme.KeyColor, m.Name)+d.colorize(d.theme.MetaColor, d.methodSignature(m.Type)))
+		fmt.Fprintln(w)
+	}
+}
+
+// interfaceMethodNames lists the methods of interface type t, e.g. {Read, Write}.
+func interfaceMethodNames(t reflect.Type) string {
+	names := make([]string, t.NumMethod())
+	for i := range names {
+		names[i] = t.Method(i).Name
+	}
+	return "{" + strings.Join(names, ", ") + "}"
+}
+
+// methodSignature formats a method's func type without its receiver, e.g. "(string, ...int) error".
+func (d *Dumper) methodSignature(ft reflect.Type) string {
+	params := make([]string, 0, ft.NumIn())
+	for i := 1; i < ft.NumIn(); i++ {
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			params = append(params, "..."+d.getTypeString(ft.In(i).Elem()))
+			continue
+		}
+		params = append(params, d.getTypeString(ft.In(i)))
+	}
+	sig := "(" + strings.Join(params, ", ") + ")"
+
+	results := make([]string, 0, ft.NumOut())
+	for i := 0; i < ft.NumOut(); i++ {
+		results = append(results, d.getTypeString(ft.Out(i)))
+	}
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// hasTagOption reports whether the field's godump struct tag lists opt, e.g. `godump:"text"`.
+func hasTagOption(field reflect.StructField, opt string) bool {
+	for _, o := range strings.Split(field.Tag.Get("godump"), ",") {
+		if strings.TrimSpace(o) == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// tagOptionValue returns the value of a key=value option in the field's godump struct tag,
+// e.g. "4" for keep in `godump:"redact,keep=4"`.
+func tagOptionValue(field reflect.StructField, key string) (string, bool) {
+	for _, o := range strings.Split(field.Tag.Get("godump"), ",") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(o), "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// taggedText renders a byte slice or array field tagged `godump:"text"` as a quoted string.
+func (d *Dumper) taggedText(field reflect.StructField, v reflect.Value) (string, bool) {
+	if !hasTagOption(field, "text") {
+		return "", false
+	}
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() != reflect.Uint8 {
+		return "", false
+	}
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return "", false
+	}
+
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	str := escapeControl(d.obfuscateString(string(b)))
+	if utf8.RuneCountInString(str) > d.maxStringLen {
+		str = string([]rune(str)[:d.maxStringLen]) + "…"
+	}
+	return d.colorize(d.theme.SymbolColor, `"`) + d.colorize(d.theme.StringColor, str) + d.colorize(d.theme.SymbolColor, `"`) +
+		d.colorize(d.typeColor(v.Type()), " #"+d.getTypeString(v.Type())), true
+}
+
+// nonDefaultFields filters field indexes down to those whose values differ from the zero value.
+func nonDefaultFields(v reflect.Value, fields []int) []int {
+	out := fields[:0:0]
+	for _, i := range fields {
+		if !isDefaultValue(v.Field(i)) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// isDefaultValue reports whether v is equivalent to its type's zero value, comparing
+// recursively so that empty collections and pointers to zero values also count as default.
+func isDefaultValue(v reflect.Value) bool {
+	return isDefaultValueSeen(v, map[uintptr]bool{})
+}
+
+// isDefaultValueSeen implements isDefaultValue. seen holds the pointers on the current
+// path; a pointer that leads back into it is part of a cycle and counts as non-default.
+func isDefaultValueSeen(v reflect.Value, seen map[uintptr]bool) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		if v.Kind() == reflect.Ptr {
+			ptr := v.Pointer()
+			if seen[ptr] {
+				return false
+			}
+			seen[ptr] = true
+			defer delete(seen, ptr)
+		}
+		return isDefaultValueSeen(v.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isDefaultValueSeen(v.Field(i), seen) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isDefaultValueSeen(v.Index(i), seen) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// pluralize formats a count with its noun, e.g. "(1 field)" or "(3 fields)".
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("(%d %s)", n, noun)
+	}
+	return fmt.Sprintf("(%d %ss)", n, noun)
+}
+
+// shouldIncludeField returns true when the field survives elide/include/exclude filtering (elide, then include, takes precedence).
 func (d *Dumper) shouldIncludeField(name string) bool {
+	if _, ok := d.elideFields[name]; ok {
+		return false
+	}
 	if len(d.includeFields) > 0 && !d.matchesAny(name, d.includeFields, FieldMatchExact) {
 		return false
 	}
@@ -1203,7 +4652,17 @@ func (d *Dumper) shouldIncludeField(name string) bool {
 
 // shouldRedactField reports whether the field should be replaced with the redacted placeholder.
 func (d *Dumper) shouldRedactField(name string) bool {
-	return d.matchesAny(name, d.redactFields, d.redactMatchMode)
+	return d.matchesAny(name, d.redactFields, d.redactMatchMode) || d.matchesRedactPattern(name)
+}
+
+// matchesRedactPattern reports whether name matches any WithRedactRegex pattern.
+func (d *Dumper) matchesRedactPattern(name string) bool {
+	for _, re := range d.redactPatterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
 }
 
 // matchesAny checks whether name matches any of the candidates using the provided mode.
@@ -1241,10 +4700,26 @@ func (d *Dumper) matchesAny(name string, candidates []string, mode FieldMatchMod
 
 func (d *Dumper) redactedValue(v reflect.Value) string {
 	if !v.IsValid() {
-		return d.colorize(colorRed, "<redacted>")
+		return d.colorize(d.theme.ErrorColor, "<redacted>")
 	}
 	typeStr := d.getTypeString(v.Type())
-	return d.colorize(colorRed, "<redacted>") + d.colorize(colorGray, " #"+typeStr)
+	return d.colorize(d.theme.ErrorColor, "<redacted>") + d.colorize(d.theme.TypeColor, " #"+typeStr)
+}
+
+// redactedTaggedValue masks a field tagged `godump:"redact"`. String fields tagged with
+// keep=N also show their last N characters, unless that would reveal the whole value.
+func (d *Dumper) redactedTaggedValue(field reflect.StructField, v reflect.Value) string {
+	keep, _ := tagOptionValue(field, "keep")
+	n, err := strconv.Atoi(keep)
+	if err != nil || n <= 0 || v.Kind() != reflect.String {
+		return d.redactedValue(v)
+	}
+	runes := []rune(v.String())
+	if len(runes) <= n {
+		return d.redactedValue(v)
+	}
+	return d.colorize(d.theme.ErrorColor, "<redacted>") + d.colorize(d.theme.StringColor, "…"+escapeControl(string(runes[len(runes)-n:]))) +
+		d.colorize(d.theme.TypeColor, " #"+d.getTypeString(v.Type()))
 }
 
 // isComplexValue reports whether v unwraps to a struct/map/slice/array.
@@ -1304,3 +4779,74 @@ func shouldTruncateAtDepth(v reflect.Value, indent, maxDepth int) bool {
 		return false
 	}
 }
+
+// wrapToWidth breaks lines longer than WithMaxWidth columns, indenting continuations one
+// level past the line's own indentation. ANSI escapes take no width and the active color
+// is carried over to the continuation.
+func (d *Dumper) wrapToWidth(s string) string {
+	if d.maxWidth <= 0 || d.htmlOutput {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	lines := strings.Split(s, "\n")
+	for n, line := range lines {
+		if n > 0 {
+			sb.WriteByte('\n')
+		}
+		plain := stripANSI(line)
+		if utf8.RuneCountInString(plain) <= d.maxWidth {
+			sb.WriteString(line)
+			continue
+		}
+
+		cont := len(plain) - len(strings.TrimLeft(plain, " ")) + d.indentWidth
+		if cont > d.maxWidth/2 {
+			cont = d.maxWidth / 2
+		}
+
+		col := 0
+		active := ""
+		for i := 0; i < len(line); {
+			if line[i] == ansiEscape {
+				end := i + 1
+				if end < len(line) && line[end] == '[' {
+					end++
+					for end < len(line) && (line[end] < '@' || line[end] > '~') {
+						end++
+					}
+					if end < len(line) {
+						end++
+					}
+				}
+				seq := line[i:end]
+				if seq == colorReset {
+					active = ""
+				} else {
+					active = seq
+				}
+				sb.WriteString(seq)
+				i = end
+				continue
+			}
+
+			if col == d.maxWidth {
+				if active != "" {
+					sb.WriteString(colorReset)
+				}
+				sb.WriteString("\n" + strings.Repeat(" ", cont) + active)
+				col = cont
+				if line[i] == ' ' {
+					i++
+					continue
+				}
+			}
+			_, size := utf8.DecodeRuneInString(line[i:])
+			sb.WriteString(line[i : i+size])
+			i += size
+			col++
+		}
+	}
+	return sb.String()
+}
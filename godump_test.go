@@ -1,23 +1,37 @@
 package godump
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net/netip"
 	"os"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
 	"text/tabwriter"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	assert "github.com/goforj/godump/internal/testassert"
 	require "github.com/goforj/godump/internal/testrequire"
 )
 
+// TestMain pins the terminal color profile so expectations written against the default
+// 256-color palette hold regardless of the terminal running the tests.
+func TestMain(m *testing.M) {
+	os.Unsetenv("COLORTERM")
+	os.Unsetenv("TERM")
+	os.Exit(m.Run())
+}
+
 func newDumperT(t *testing.T, opts ...Option) *Dumper {
 	t.Helper()
 
@@ -74,6 +88,69 @@ func TestCycleReference(t *testing.T) {
 	assert.Contains(t, out, "↩︎ &1")
 }
 
+func TestPointerIDs(t *testing.T) {
+	type Node struct {
+		Name string
+	}
+	type Graph struct {
+		First  *Node
+		Alias  *Node
+		Second *Node
+	}
+
+	shared := &Node{Name: "shared"}
+	out := newDumperT(t, WithPointerIDs()).DumpStr(&Graph{First: shared, Alias: shared, Second: &Node{Name: "other"}})
+
+	assert.Contains(t, out, "&1 #*godump.Graph {")
+	assert.Contains(t, out, "+First  => &2 #*godump.Node {")
+	assert.Contains(t, out, "+Alias  => ↩︎ &2")
+	assert.Contains(t, out, "+Second => &3 #*godump.Node {")
+
+	plain := dumpStrT(t, &Graph{First: shared, Alias: shared})
+	assert.NotContains(t, plain, "&1 #")
+	assert.Contains(t, plain, "+Alias  => ↩︎ &1")
+}
+
+func TestReferenceIDsRestartPerDump(t *testing.T) {
+	type Node struct {
+		Next *Node
+	}
+
+	d := newDumperT(t, WithPointerIDs(), WithMaxDepth(3), WithoutColor())
+	first := &Node{}
+	first.Next = first
+	before := d.DumpStr(first)
+	assert.Contains(t, before, "&1 #*godump.Node {")
+
+	require.True(t, d == d.Reset())
+	assert.Equal(t, 3, d.maxDepth)
+	assert.True(t, d.pointerIDs)
+
+	second := &Node{}
+	second.Next = second
+	after := d.DumpStr(second)
+	assert.Contains(t, after, "&1 #*godump.Node {")
+	assert.Contains(t, after, "+Next => ↩︎ &1")
+	assert.NotContains(t, after, "&2")
+}
+
+func TestResetRedetectsColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	d := NewDumper(WithoutHeader())
+	assert.NotContains(t, d.DiffStr(1, 2), colorRed+"-")
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	assert.NotContains(t, d.DiffStr(1, 2), colorRed+"-")
+	d.Reset()
+	assert.Contains(t, d.DiffStr(1, 2), colorRed+"-")
+
+	// An explicit WithoutColor is configuration and survives Reset.
+	plain := NewDumper(WithoutHeader(), WithoutColor())
+	plain.Reset()
+	assert.NotContains(t, plain.DiffStr(1, 2), "\x1b[")
+}
+
 func TestConcurrentDumpReferenceIDs(t *testing.T) {
 	type Node struct {
 		Next *Node
@@ -107,6 +184,46 @@ func TestConcurrentDumpReferenceIDs(t *testing.T) {
 	}
 }
 
+func TestConcurrentDumpsMatchSequentialOutput(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+		Peer *Node
+	}
+	build := func() *Node {
+		a, b := &Node{Name: "a"}, &Node{Name: "b"}
+		a.Next, a.Peer = b, b
+		b.Next = a
+		return a
+	}
+
+	d := NewDumper(WithPointerIDs())
+	want := d.DumpStr(build())
+	wantPkg := DumpStr(build())
+
+	const runs = 50
+	var wg sync.WaitGroup
+	errs := make(chan string, 2*runs)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := d.DumpStr(build()); got != want {
+				errs <- got
+			}
+			if got := DumpStr(build()); got != wantPkg {
+				errs <- got
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for got := range errs {
+		t.Errorf("concurrent dump differs from sequential output:\n%s", got)
+	}
+}
+
 func TestMaxDepth(t *testing.T) {
 	type Node struct {
 		Child *Node
@@ -296,6 +413,33 @@ func TestMaxDepthAllowsArrayScalars(t *testing.T) {
 	assert.NotContains(t, out, "0 => 1")
 }
 
+func TestMaxPathDepth(t *testing.T) {
+	type Leaf struct {
+		Value int
+	}
+	type Middle struct {
+		Leaf Leaf
+	}
+	type Root struct {
+		Middle Middle
+		Grid   [][][]Leaf
+	}
+
+	d := newDumperT(t, WithMaxPathDepth(2))
+	out := d.DumpStr(Root{
+		Middle: Middle{Leaf: Leaf{Value: 1}},
+		Grid:   [][][]Leaf{{{{Value: 42}}}},
+	})
+
+	assert.Contains(t, out, "+Middle => #godump.Middle {")
+	assert.Contains(t, out, "+Leaf => ... (max path depth)")
+	assert.Contains(t, out, "+Value => 42 #int")
+	assert.NotContains(t, out, "1 #int")
+
+	out = newDumperT(t, WithMaxDepth(2)).DumpStr(Root{Grid: [][][]Leaf{{{{Value: 42}}}}})
+	assert.Contains(t, out, "... (max depth)")
+}
+
 func TestMaxDepthEdgeCases(t *testing.T) {
 	type Inner struct {
 		ID int
@@ -348,6 +492,39 @@ func TestMaxDepthEdgeCases(t *testing.T) {
 	assert.Contains(t, out, "[]int(nil)")
 }
 
+func TestEmptyArrayIsNotNil(t *testing.T) {
+	out := dumpStrT(t, [0]int{})
+	assert.Equal(t, "#[0]int [\n]\n", out)
+	assert.NotContains(t, out, "(nil)")
+
+	type Holder struct {
+		Empty [0]int
+		Nil   []int
+	}
+	out = dumpStrT(t, Holder{})
+	assert.Contains(t, out, "+Empty => #[0]int [\n  ]")
+	assert.Contains(t, out, "+Nil => []int(nil)")
+}
+
+func TestHeterogeneousAnyContainers(t *testing.T) {
+	type User struct {
+		Name string
+	}
+
+	out := dumpStrT(t, []any{1, "x", User{Name: "a"}, &User{Name: "b"}, 2.5, nil})
+	assert.Contains(t, out, "#[]interface {} [")
+	assert.Contains(t, out, "0 => 1 #int")
+	assert.Contains(t, out, `1 => "x" #string`)
+	assert.Contains(t, out, "2 => #godump.User {")
+	assert.Contains(t, out, "3 => #*godump.User {")
+	assert.Contains(t, out, "4 => 2.500000 #float64")
+	assert.Contains(t, out, "5 => interface {}(nil)")
+
+	out = Snapshot(map[string]any{"id": int64(7), "user": User{}})
+	assert.Contains(t, out, "id   => 7 #int64")
+	assert.Contains(t, out, "user => #godump.User {")
+}
+
 func TestChanValueRendering(t *testing.T) {
 	var nilCh chan int
 	out := dumpStrT(t, nilCh)
@@ -358,6 +535,32 @@ func TestChanValueRendering(t *testing.T) {
 	assert.Contains(t, out, "chan int")
 }
 
+func TestNilFormatter(t *testing.T) {
+	type Holder struct {
+		Map   map[string]int
+		Slice []int
+		Ptr   *int
+	}
+
+	d := newDumperT(t, WithNilFormatter(func(t reflect.Type) string {
+		switch t.Kind() {
+		case reflect.Map:
+			return "<nil map>"
+		case reflect.Slice:
+			return "<nil slice>"
+		default:
+			return "nil"
+		}
+	}))
+	out := d.DumpStr(Holder{})
+
+	assert.Contains(t, out, "+Map   => <nil map>")
+	assert.Contains(t, out, "+Slice => <nil slice>")
+	assert.Contains(t, out, "+Ptr   => nil")
+
+	assert.Contains(t, dumpStrT(t, Holder{}), "map[string]int(nil)")
+}
+
 func TestStringerNilPointer(t *testing.T) {
 	var tptr *time.Time
 	d := newDumperT(t)
@@ -375,6 +578,107 @@ func TestStringerNilPointer(t *testing.T) {
 	assert.Contains(t, out, "time.Time(nil)")
 }
 
+func TestMatrixView(t *testing.T) {
+	d := newDumperT(t, WithMatrixView())
+
+	out := d.DumpStr([][]int{{1, 2, 3}, {10, 200, 3}, {-5, 0, 30}})
+	assert.Contains(t, out, "#[][]int [\n")
+	assert.Contains(t, out, "  [ 1   2  3]\n")
+	assert.Contains(t, out, "  [10 200  3]\n")
+	assert.Contains(t, out, "  [-5   0 30]\n")
+
+	ragged := d.DumpStr([][]int{{1, 2}, {3}})
+	assert.Contains(t, ragged, "0 => #[]int [")
+
+	nested := d.DumpStr([][]any{{1, 2}, {3, 4}})
+	assert.Contains(t, nested, "0 => #[]interface {} [")
+
+	assert.NotContains(t, dumpStrT(t, [][]int{{1, 2}, {3, 4}}), "[1 2]")
+}
+
+func TestMarkPointers(t *testing.T) {
+	type Holder struct {
+		Items *[]int
+		Table *map[string]int
+		Count *int
+		Plain []int
+	}
+
+	items := []int{1}
+	table := map[string]int{"a": 1}
+	count := 3
+	d := newDumperT(t, WithMarkPointers())
+
+	assert.True(t, strings.HasPrefix(d.DumpStr(&items), "*#*[]int ["))
+
+	out := d.DumpStr(Holder{Items: &items, Table: &table, Count: &count, Plain: items})
+	assert.Contains(t, out, "+Items => *#*[]int [")
+	assert.Contains(t, out, "+Table => *#*map[string]int {")
+	assert.Contains(t, out, "+Count => *3 #*int")
+	assert.Contains(t, out, "+Plain => #[]int [")
+
+	assert.True(t, strings.HasPrefix(dumpStrT(t, &items), "#*[]int ["))
+}
+
+func TestCollapseSingleFieldStructs(t *testing.T) {
+	type Celsius struct {
+		V float64
+	}
+	type Name struct {
+		Value string
+	}
+	type hidden struct {
+		v int
+	}
+	type Reading struct {
+		Temp   Celsius
+		Label  Name
+		Secret hidden
+		Ptr    *Celsius
+	}
+
+	d := newDumperT(t, WithCollapseSingleFieldStructs())
+	assert.Equal(t, "#godump.Celsius(21.500000)\n", d.DumpStr(Celsius{V: 21.5}))
+
+	out := d.DumpStr(Reading{Temp: Celsius{V: 1}, Label: Name{Value: "x"}, Ptr: &Celsius{V: 2}})
+	assert.Contains(t, out, "+Temp   => #godump.Celsius(1.000000)")
+	assert.Contains(t, out, `+Label  => #godump.Name("x")`)
+	assert.Contains(t, out, "+Secret => #godump.hidden {")
+	assert.Contains(t, out, "+Ptr => #*godump.Celsius(2.000000)")
+
+	assert.Contains(t, dumpStrT(t, Celsius{V: 21.5}), "#godump.Celsius {")
+}
+
+func TestCollapseSingleFieldStructsHonorsHiddenFields(t *testing.T) {
+	type hiddenOnly struct {
+		Password string `godump:"-"`
+	}
+	type oneVisible struct {
+		ID       int
+		Password string `godump:"-"`
+	}
+	d := newDumperT(t, WithCollapseSingleFieldStructs())
+
+	out := d.DumpStr(hiddenOnly{Password: "hunter2"})
+	assert.NotContains(t, out, "hunter2")
+	assert.Equal(t, "#godump.hiddenOnly {}\n", out)
+
+	out = d.DumpStr(oneVisible{ID: 7, Password: "hunter2"})
+	assert.Equal(t, "#godump.oneVisible(7)\n", out)
+}
+
+func TestCollapseSingleFieldStructsHonorsRedactTag(t *testing.T) {
+	type secret struct {
+		Token string `godump:"redact"`
+	}
+	type Holder struct {
+		S secret
+	}
+	out := newDumperT(t, WithCollapseSingleFieldStructs()).DumpStr(Holder{S: secret{Token: "hunter2"}})
+	assert.NotContains(t, out, "hunter2")
+	assert.Contains(t, out, "+Token => <redacted> #string")
+}
+
 func TestMapOutput(t *testing.T) {
 	m := map[string]int{"a": 1, "b": 2}
 	out := dumpStrT(t, m)
@@ -383,6 +687,92 @@ func TestMapOutput(t *testing.T) {
 	assert.Contains(t, out, "b => 2")
 }
 
+func TestQuoteMapKeys(t *testing.T) {
+	m := map[string]int{"a": 1, "two words": 2}
+
+	out := newDumperT(t, WithQuoteMapKeys(true)).DumpStr(m)
+	assert.Contains(t, out, `"a"         => 1 #int`)
+	assert.Contains(t, out, `"two words" => 2 #int`)
+
+	out = newDumperT(t, WithQuoteMapKeys(false)).DumpStr(m)
+	assert.Contains(t, out, "a         => 1 #int")
+	assert.NotContains(t, out, `"a"`)
+
+	out = newDumperT(t, WithQuoteMapKeys(true)).DumpStr(map[int][]string{1: {"x"}})
+	assert.Contains(t, out, "1 => #[]string [")
+	assert.Contains(t, out, `0 => "x" #string`)
+}
+
+func TestMapKeysAlignPerLevel(t *testing.T) {
+	config := map[string]any{
+		"db": map[string]any{
+			"host":            "localhost",
+			"max_connections": 10,
+		},
+		"name":          "app",
+		"feature_flags": []string{"beta"},
+	}
+
+	out := Snapshot(config)
+	assert.Contains(t, out, "\n   db            => #map[string]interface {} {\n")
+	assert.Contains(t, out, "\n     host            => \"localhost\" #string\n")
+	assert.Contains(t, out, "\n     max_connections => 10 #int\n")
+	assert.Contains(t, out, "\n   feature_flags => #[]string [\n")
+	assert.Contains(t, out, "\n   name          => \"app\" #string\n")
+}
+
+func TestSetRendering(t *testing.T) {
+	set := map[string]struct{}{"charlie": {}, "alpha": {}, "bravo": {}}
+	out := dumpStrT(t, set)
+	assert.Equal(t, "#map[string]struct {} {alpha, bravo, charlie}\n", out)
+	assert.NotContains(t, out, "=>")
+
+	type Perms struct {
+		IDs map[int]struct{}
+	}
+	out = dumpStrT(t, Perms{IDs: map[int]struct{}{10: {}, 2: {}}})
+	assert.Contains(t, out, "+IDs => #map[int]struct {} {2, 10}")
+
+	out = newDumperT(t, WithMaxItems(1)).DumpStr(set)
+	assert.Equal(t, "#map[string]struct {} {alpha, ... (truncated)}\n", out)
+
+	assert.Equal(t, "#map[string]struct {} {}\n", dumpStrT(t, map[string]struct{}{}))
+
+	letters := map[string]struct{}{}
+	for _, k := range strings.Split("abcdefghijklmnop", "") {
+		letters[k] = struct{}{}
+	}
+	sorted := "#map[string]struct {} {a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p}\n"
+	unsorted := newDumperT(t, WithSortedMapKeys(false))
+	reordered := false
+	for i := 0; i < 50 && !reordered; i++ {
+		out = unsorted.DumpStr(letters)
+		assert.Equal(t, len(sorted), len(out))
+		reordered = out != sorted
+	}
+	assert.True(t, reordered)
+}
+
+func TestSnapshot(t *testing.T) {
+	v := map[string]any{
+		"zeta":  3,
+		"alpha": map[int]string{10: "ten", 2: "two", 7: "seven"},
+		"mid":   []string{"x"},
+	}
+
+	first := Snapshot(v)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, first, Snapshot(v))
+	}
+
+	assert.NotContains(t, first, string(ansiEscape))
+	assert.NotContains(t, first, "<#dump")
+	assert.True(t, strings.Index(first, "alpha") < strings.Index(first, "mid"))
+	assert.True(t, strings.Index(first, "mid") < strings.Index(first, "zeta"))
+	assert.True(t, strings.Index(first, "2  =>") < strings.Index(first, "7  =>"))
+	assert.True(t, strings.Index(first, "7  =>") < strings.Index(first, "10 =>"))
+}
+
 func TestSliceOutput(t *testing.T) {
 	s := []string{"one", "two"}
 	out := dumpStrT(t, s)
@@ -430,6 +820,54 @@ func TestFuncPlaceholder(t *testing.T) {
 	assert.Contains(t, out, "func()")
 }
 
+func namedHelper(int) string { return "" }
+
+type greeter struct {
+	name string
+}
+
+func (g *greeter) Hello() string { return "hello " + g.name }
+
+func (g greeter) Bye() string { return "bye " + g.name }
+
+func TestMethodFuncRendering(t *testing.T) {
+	g := &greeter{name: "x"}
+
+	out := dumpStrT(t, g.Hello)
+	assert.Contains(t, out, "(*godump.greeter).Hello bound func() string")
+
+	out = dumpStrT(t, g.Bye)
+	assert.Contains(t, out, "godump.greeter.Bye bound func() string")
+
+	out = dumpStrT(t, (*greeter).Hello)
+	assert.Contains(t, out, "(*godump.greeter).Hello func(*godump.greeter) string")
+	assert.NotContains(t, out, "bound")
+
+	out = dumpStrT(t, greeter.Bye)
+	assert.Contains(t, out, "godump.greeter.Bye func(godump.greeter) string")
+
+	out = dumpStrT(t, func() {})
+	assert.True(t, strings.HasPrefix(out, "func() @"))
+	assert.True(t, strings.HasPrefix(dumpStrT(t, namedHelper), "func(int) string @"))
+}
+
+func TestIsClosureName(t *testing.T) {
+	assert.True(t, isClosureName("func1"))
+	assert.True(t, isClosureName("func12"))
+	assert.False(t, isClosureName("func"))
+	assert.False(t, isClosureName("funcName"))
+	assert.False(t, isClosureName("Hello"))
+}
+
+func TestFuncSourceLocation(t *testing.T) {
+	out := dumpStrT(t, namedHelper)
+	assert.Contains(t, out, "func(int) string @ godump_test.go:")
+	assert.Contains(t, out, " #func(int) string")
+
+	out = dumpStrT(t, strings.ToUpper)
+	assert.Contains(t, out, "@ strings.go:")
+}
+
 func TestSpecialTypes(t *testing.T) {
 	type Unsafe struct {
 		Ptr unsafe.Pointer
@@ -532,6 +970,155 @@ func TestDetectColorVariants(t *testing.T) {
 	})
 }
 
+func TestColorProfileFromTerminal(t *testing.T) {
+	t.Run("truecolor terminal", func(t *testing.T) {
+		t.Setenv("COLORTERM", "truecolor")
+		out := NewDumper().colorize(colorYellow, "test")
+		assert.Equal(t, colorizeTrueColor(colorYellow, "test"), out)
+		assert.Contains(t, out, "\x1b[38;2;")
+	})
+
+	t.Run("direct color TERM", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-direct")
+		assert.Contains(t, NewDumper().colorize(colorYellow, "test"), "\x1b[38;2;")
+	})
+
+	t.Run("dumb terminal", func(t *testing.T) {
+		t.Setenv("TERM", "dumb")
+		assert.False(t, detectColor())
+		assert.Equal(t, "test", NewDumper().colorize(colorYellow, "test"))
+	})
+
+	t.Run("FORCE_COLOR overrides dumb terminal", func(t *testing.T) {
+		t.Setenv("TERM", "dumb")
+		t.Setenv("FORCE_COLOR", "1")
+		assert.Equal(t, colorYellow+"test"+colorReset, NewDumper().colorize(colorYellow, "test"))
+	})
+
+	t.Run("NO_COLOR overrides truecolor", func(t *testing.T) {
+		t.Setenv("COLORTERM", "truecolor")
+		t.Setenv("NO_COLOR", "1")
+		assert.Equal(t, "test", NewDumper().colorize(colorYellow, "test"))
+	})
+}
+
+func TestTrueColor(t *testing.T) {
+	out := NewDumper(WithTrueColor()).DumpStr(map[string]int{"a": 1})
+	assert.Contains(t, out, "\x1b[38;2;153;153;153m#map[string]int"+colorReset)
+	assert.Contains(t, out, "\x1b[38;2;64;192;255m1"+colorReset)
+	assert.NotContains(t, out, colorGray)
+
+	out = NewDumper(WithoutColor(), WithTrueColor()).DumpStr(1)
+	assert.NotContains(t, out, string(ansiEscape))
+
+	assert.Equal(t, "\x1b[1mx"+colorReset, colorizeTrueColor("\x1b[1m", "x"))
+	assert.Contains(t, NewDumper(WithTrueColor()).DumpHTML(1), `<span style="color:`)
+}
+
+func TestTrueColorThemeRGB(t *testing.T) {
+	theme := DefaultTheme()
+	theme.StringColor = RGB(0, 173, 216)
+	assert.Equal(t, "\x1b[38;2;0;173;216m", theme.StringColor)
+
+	out := NewDumper(WithTheme(theme), WithTrueColor()).DumpStr("go")
+	assert.Contains(t, out, "\x1b[38;2;0;173;216mgo"+colorReset)
+
+	html := NewDumper(WithTheme(theme)).DumpHTML("go")
+	assert.Contains(t, html, `<span style="color:#00add8">go</span>`)
+}
+
+func TestTrueColorNoColorWins(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	theme := DefaultTheme()
+	theme.StringColor = RGB(0, 173, 216)
+
+	out := NewDumper(WithTheme(theme), WithTrueColor()).DumpStr("go")
+	assert.Equal(t, `"go" #string`+"\n", out)
+}
+
+func TestPackageColors(t *testing.T) {
+	type Local struct {
+		Reader strings.Reader
+	}
+
+	d := NewDumper(WithPackageColors())
+	localColor := d.typeColor(reflect.TypeOf(Local{}))
+	readerColor := d.typeColor(reflect.TypeOf(strings.Reader{}))
+
+	assert.True(t, localColor != readerColor)
+	assert.Equal(t, localColor, d.typeColor(reflect.TypeOf([]*Local{})))
+	assert.Equal(t, colorGray, d.typeColor(reflect.TypeOf(1)))
+	assert.Equal(t, colorGray, NewDumper().typeColor(reflect.TypeOf(Local{})))
+
+	d.colorizer = colorizeANSI
+	out := d.DumpStr(Local{})
+	assert.Contains(t, out, localColor+"#godump.Local"+colorReset)
+	assert.Contains(t, out, readerColor+"#strings.Reader"+colorReset)
+	assert.Equal(t, out, d.DumpStr(Local{}))
+
+	theme := DefaultTheme()
+	theme.PackageColors = []string{RGB(9, 9, 9)}
+	d = NewDumper(WithPackageColors(), WithTheme(theme))
+	assert.Equal(t, RGB(9, 9, 9), d.typeColor(reflect.TypeOf(Local{})))
+	assert.Equal(t, RGB(9, 9, 9), d.typeColor(reflect.TypeOf(strings.Reader{})))
+
+	d = NewDumper(WithPackageColors(), WithTheme(MonochromeTheme()))
+	assert.Equal(t, "", d.typeColor(reflect.TypeOf(Local{})))
+}
+
+func TestSemanticColors(t *testing.T) {
+	type Result struct {
+		Err     error
+		OK      bool
+		Done    bool
+		Count   int
+		Retries int
+	}
+	v := Result{Err: errors.New("connection refused"), Done: true, Count: 3}
+
+	d := NewDumper(WithSemanticColors())
+	d.colorizer = colorizeANSI
+	out := d.DumpStr(v)
+	assert.Contains(t, out, colorRed+"connection refused"+colorReset)
+	assert.Contains(t, out, "#*errors.errorString")
+	assert.Contains(t, out, colorGray+"false"+colorReset)
+	assert.Contains(t, out, colorGreen+"true"+colorReset)
+	assert.Contains(t, out, colorCyan+"3"+colorReset)
+	assert.Contains(t, out, colorGray+"0"+colorReset)
+
+	plain := NewDumper()
+	plain.colorizer = colorizeANSI
+	out = plain.DumpStr(v)
+	assert.NotContains(t, out, colorRed+"connection refused")
+	assert.Contains(t, out, colorYellow+"true"+colorReset)
+	assert.Contains(t, out, colorCyan+"0"+colorReset)
+
+	theme := DefaultTheme()
+	theme.ZeroColor = RGB(1, 2, 3)
+	theme.BoolColor = RGB(4, 5, 6)
+	themed := NewDumper(WithSemanticColors(), WithTheme(theme))
+	themed.colorizer = colorizeANSI
+	out = themed.DumpStr(v)
+	assert.Contains(t, out, RGB(1, 2, 3)+"false"+colorReset)
+	assert.Contains(t, out, RGB(4, 5, 6)+"true"+colorReset)
+	assert.NotContains(t, out, colorGreen)
+}
+
+type nilDerefErr struct {
+	msg string
+}
+
+func (e *nilDerefErr) Error() string { return e.msg }
+
+func TestSemanticColorsTypedNilError(t *testing.T) {
+	type Result struct {
+		Err error
+	}
+	var typed *nilDerefErr
+	out := newDumperT(t, WithSemanticColors()).DumpStr(Result{Err: typed})
+	assert.Contains(t, out, "+Err => *godump.nilDerefErr(nil)")
+}
+
 func TestWithoutColorOverridesColorDetection(t *testing.T) {
 	t.Setenv("FORCE_COLOR", "1")
 
@@ -709,6 +1296,24 @@ func TestPrintValue_Func(t *testing.T) {
 	assert.Contains(t, buf.String(), "func()")
 }
 
+func TestFallbackString(t *testing.T) {
+	assert.Equal(t, "<unrenderable kind: invalid>", fallbackString(reflect.Value{}))
+	assert.Equal(t, "42", fallbackString(reflect.ValueOf(42)))
+
+	ch := make(chan int)
+	assert.Equal(t, fmt.Sprintf("%v", ch), fallbackString(reflect.ValueOf(ch)))
+}
+
+func TestNilPointerInPointerChain(t *testing.T) {
+	type Holder struct {
+		PP **int
+	}
+
+	var p *int
+	assert.Contains(t, dumpStrT(t, &p), "*int(nil)")
+	assert.Contains(t, dumpStrT(t, Holder{PP: &p}), "+PP => *int(nil)")
+}
+
 func TestMaxDepthTruncation(t *testing.T) {
 	type Node struct {
 		Next *Node
@@ -751,7 +1356,7 @@ func TestMapTruncation(t *testing.T) {
 		largeMap[i] = i
 	}
 	out := dumpStrT(t, largeMap)
-	assert.Contains(t, out, "... (truncated)")
+	assert.Contains(t, out, "... (100 more truncated)")
 }
 
 func TestNilInterfaceTypePrint(t *testing.T) {
@@ -774,10 +1379,67 @@ func TestNilChan(t *testing.T) {
 	}
 }
 
+func TestPointerOfKinds(t *testing.T) {
+	x := 1
+	ch := make(chan int)
+	fn := func() {}
+	m := map[string]int{}
+	sl := []int{1}
+
+	for name, v := range map[string]any{
+		"chan":   ch,
+		"func":   fn,
+		"map":    m,
+		"ptr":    &x,
+		"slice":  sl,
+		"unsafe": unsafe.Pointer(&x),
+	} {
+		assert.True(t, pointerOf(reflect.ValueOf(v)) != 0, name)
+	}
+
+	for name, v := range map[string]any{
+		"int":    x,
+		"string": "s",
+		"struct": struct{}{},
+		"array":  [1]int{},
+	} {
+		assert.Equal(t, uintptr(0), pointerOf(reflect.ValueOf(v)), name)
+	}
+	assert.Equal(t, uintptr(0), pointerOf(reflect.Value{}))
+	assert.Equal(t, uintptr(0), pointerOf(reflect.ValueOf((*int)(nil))))
+}
+
+func TestDumpPointerKindsWithFinalizer(t *testing.T) {
+	type handle struct {
+		ID int
+	}
+	type Holder struct {
+		ch  chan int
+		fn  func()
+		m   map[string]int
+		p   *handle
+		s   []int
+		u   unsafe.Pointer
+		nch chan int
+		nfn func()
+	}
+
+	h := &handle{ID: 7}
+	runtime.SetFinalizer(h, func(*handle) {})
+	v := Holder{ch: make(chan int), fn: func() {}, m: map[string]int{"a": 1}, p: h, s: []int{1}, u: unsafe.Pointer(h)}
+
+	out := newDumperT(t, WithPointerIDs()).DumpStr(v)
+	assert.Contains(t, out, "=> &1 #*godump.handle {")
+	assert.Contains(t, out, "-u   => unsafe.Pointer(0x")
+	assert.Contains(t, out, "-nch => chan int(nil)")
+	assert.Contains(t, out, "-nfn => func()(nil)")
+	runtime.KeepAlive(h)
+}
+
 func TestTruncatedSlice(t *testing.T) {
 	slice := make([]int, 101)
 	out := dumpStrT(t, slice)
-	if !strings.Contains(out, "... (truncated)") {
+	if !strings.Contains(out, "... (1 more truncated)") {
 		t.Error("Expected slice to be truncated")
 	}
 }
@@ -785,25 +1447,94 @@ func TestTruncatedSlice(t *testing.T) {
 func TestCustomTruncatedSlice(t *testing.T) {
 	slice := make([]int, 3)
 	out := newDumperT(t, WithMaxItems(2)).DumpStr(slice)
-	if !strings.Contains(out, "... (truncated)") {
+	if !strings.Contains(out, "... (1 more truncated)") {
 		t.Error("Expected slice to be truncated")
 	}
 
 	out = newDumperT(t, WithMaxItems(0)).DumpStr(slice)
-	if !strings.Contains(out, "... (truncated)") {
+	if !strings.Contains(out, "... (3 more truncated)") {
 		t.Error("Expected slice to be truncated")
 	}
 
 	out = newDumperT(t, WithMaxItems(-1)).DumpStr(slice)
-	if strings.Contains(out, "... (truncated)") {
+	if strings.Contains(out, "truncated") {
 		t.Error("Negative MaxItems option should not be applied")
 	}
 }
 
+func TestSampleLargeCollections(t *testing.T) {
+	v := make([]int, 100)
+	for i := range v {
+		v[i] = i * 10
+	}
+
+	out := newDumperT(t, WithSampleLargeCollections(10)).DumpStr(v)
+	for i := 0; i < 5; i++ {
+		assert.Contains(t, out, fmt.Sprintf("  %d => %d #int\n", i, i*10))
+	}
+	for i := 95; i < 100; i++ {
+		assert.Contains(t, out, fmt.Sprintf("  %d => %d #int\n", i, i*10))
+	}
+	assert.Contains(t, out, "... (90 omitted)")
+	assert.NotContains(t, out, " 5 => ")
+	assert.NotContains(t, out, "94 => ")
+	assert.NotContains(t, out, "truncated")
+
+	short := newDumperT(t, WithSampleLargeCollections(10)).DumpStr([]int{1, 2, 3})
+	assert.NotContains(t, short, "omitted")
+
+	m := map[int]int{}
+	for i := 0; i < 20; i++ {
+		m[i] = i
+	}
+	out = newDumperT(t, WithSampleLargeCollections(4)).DumpStr(m)
+	assert.Equal(t, 4, strings.Count(out, " #int"))
+	assert.Contains(t, out, "... (16 more truncated)")
+}
+
+func TestLargeArrayTruncation(t *testing.T) {
+	var v [1000]int
+	for i := range v {
+		v[i] = i
+	}
+
+	out := newDumperT(t, WithMaxItems(3)).DumpStr(v)
+	assert.True(t, strings.HasPrefix(out, "#[1000]int [\n"))
+	assert.Contains(t, out, "2 => 2 #int")
+	assert.NotContains(t, out, "3 => 3 #int")
+	assert.Contains(t, out, "... (997 more truncated)")
+}
+
+func TestArrayIndexAlignment(t *testing.T) {
+	v := make([]int, 101)
+	out := newDumperT(t, WithMaxItems(200)).DumpStr(v)
+	assert.Contains(t, out, "\n    0 => 0 #int\n")
+	assert.Contains(t, out, "\n   99 => 0 #int\n")
+	assert.Contains(t, out, "\n  100 => 0 #int\n")
+
+	out = newDumperT(t, WithMaxItems(10)).DumpStr(v)
+	assert.Contains(t, out, "\n  0 => 0 #int\n")
+	assert.Contains(t, out, "\n  9 => 0 #int\n")
+
+	out = newDumperT(t, WithArrayIndexWidth(4)).DumpStr([]int{7})
+	assert.Contains(t, out, "\n     0 => 7 #int\n")
+}
+
+func TestOneBasedIndices(t *testing.T) {
+	out := newDumperT(t, WithOneBasedIndices()).DumpStr([]string{"a", "b"})
+	assert.Equal(t, "#[]string [\n  1 => \"a\" #string\n  2 => \"b\" #string\n]\n", out)
+
+	out = newDumperT(t, WithOneBasedIndices()).DumpStr(make([]int, 10))
+	assert.Contains(t, out, "\n   1 => 0 #int\n")
+	assert.Contains(t, out, "\n  10 => 0 #int\n")
+
+	assert.Contains(t, dumpStrT(t, []string{"a"}), `0 => "a" #string`)
+}
+
 func TestTruncatedMap(t *testing.T) {
 	m := map[string]int{"a": 1, "b": 2, "c": 3}
 	out := newDumperT(t, WithMaxItems(1)).DumpStr(m)
-	if !strings.Contains(out, "... (truncated)") {
+	if !strings.Contains(out, "... (2 more truncated)") {
 		t.Error("Expected map to be truncated")
 	}
 }
@@ -834,6 +1565,30 @@ func TestCustomTruncatedString(t *testing.T) {
 	}
 }
 
+func TestSpecialFloats(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{name: "NaN", in: math.NaN(), want: "NaN #float64"},
+		{name: "positive infinity", in: math.Inf(1), want: "+Inf #float64"},
+		{name: "negative infinity", in: float32(math.Inf(-1)), want: "-Inf #float32"},
+		{name: "negative finite", in: -1.5, want: "-1.500000 #float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, dumpStrT(t, tt.in), tt.want)
+		})
+	}
+
+	out := NewDumper().DumpStr(math.Inf(1))
+	assert.Contains(t, out, colorYellow+"+Inf"+colorReset)
+	assert.Contains(t, newDumperT(t, WithThousandsSeparator(',')).DumpStr(math.Inf(-1)), "-Inf #float64")
+	assert.Equal(t, "", specialFloat(0))
+}
+
 func TestBoolValues(t *testing.T) {
 	out := dumpStrT(t, true, false)
 	if !strings.Contains(out, "true") || !strings.Contains(out, "false") {
@@ -1097,14 +1852,35 @@ func TestPrintDumpHeader_SkipWhenNoFrame(t *testing.T) {
 	assert.Equal(t, "", b.String()) // nothing should be written
 }
 
-type customChan chan int
+func TestPrintDumpHeader_CustomFormat(t *testing.T) {
+	var gotFile string
+	testDumper := newDumperT(t, WithHeaderFormat(func(file string, line int) string {
+		gotFile = file
+		return fmt.Sprintf("[dump] %s@%d", file, line)
+	}))
 
-func TestPrintValue_ChanNilBranch_Hardforce(t *testing.T) {
-	var buf strings.Builder
-	tw := tabwriter.NewWriter(&buf, 0, 0, 1, ' ', 0)
+	var b strings.Builder
+	testDumper.printDumpHeader(&b)
 
-	var ch customChan
-	v := reflect.ValueOf(ch)
+	assert.Equal(t, "godump_test.go", gotFile)
+	assert.True(t, strings.HasPrefix(b.String(), "[dump] godump_test.go@"))
+	assert.NotContains(t, b.String(), "<#dump")
+}
+
+func TestPrintDumpHeader_DefaultFormat(t *testing.T) {
+	var b strings.Builder
+	newDumperT(t).printDumpHeader(&b)
+	assert.True(t, strings.HasPrefix(b.String(), "<#dump // godump_test.go:"))
+}
+
+type customChan chan int
+
+func TestPrintValue_ChanNilBranch_Hardforce(t *testing.T) {
+	var buf strings.Builder
+	tw := tabwriter.NewWriter(&buf, 0, 0, 1, ' ', 0)
+
+	var ch customChan
+	v := reflect.ValueOf(ch)
 
 	assert.True(t, v.IsNil())
 	assert.Equal(t, reflect.Chan, v.Kind())
@@ -1156,6 +1932,33 @@ func TestMakeAddressable_CanAddr(t *testing.T) {
 	assert.Equal(t, v.Interface(), out.Interface()) // compare by value
 }
 
+func TestDumpStderr(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	for name, dump := range map[string]func(...any){"DumpStderr": DumpStderr, "Edump": Edump} {
+		t.Run(name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStderr, origStdout := os.Stderr, os.Stdout
+			os.Stderr = w
+			outR, outW, err := os.Pipe()
+			require.NoError(t, err)
+			os.Stdout = outW
+
+			dump(map[string]int{"a": 1})
+
+			os.Stderr, os.Stdout = origStderr, origStdout
+			_ = w.Close()
+			_ = outW.Close()
+			stderr, _ := io.ReadAll(r)
+			stdout, _ := io.ReadAll(outR)
+
+			assert.Contains(t, string(stderr), "#map[string]int {\n   a => 1 #int\n}")
+			assert.Equal(t, "", string(stdout))
+		})
+	}
+}
+
 func TestFdump_WritesToWriter(t *testing.T) {
 	var buf strings.Builder
 
@@ -1224,6 +2027,26 @@ func TestDumpWithCustomWriter(t *testing.T) {
 	}
 }
 
+func TestDumpWithExtraWriter(t *testing.T) {
+	var primary, extra bytes.Buffer
+	d := NewDumper(WithWriter(&primary), WithExtraWriter(&extra))
+	d.colorizer = colorizeANSI
+
+	d.Dump(map[string]int{"a": 1})
+	assert.Contains(t, primary.String(), string(ansiEscape))
+	assert.NotContains(t, extra.String(), string(ansiEscape))
+	assert.Equal(t, "#map[string]int {\n   a => 1 #int\n}\n", extra.String())
+
+	extra.Reset()
+	d.DumpSlice([]int{7})
+	assert.Equal(t, "[0] 7 #int\n", extra.String())
+
+	extra.Reset()
+	d.Diff(1, 2)
+	assert.Contains(t, extra.String(), "- 1 #int")
+	assert.NotContains(t, extra.String(), string(ansiEscape))
+}
+
 func wrappedDumpStr(skip int, v any) string {
 	return NewDumper(WithSkipStackFrames(skip)).DumpStr(v)
 }
@@ -1273,6 +2096,40 @@ func TestHexDumpRendering(t *testing.T) {
 	}
 }
 
+func TestHexDumpGroupSize(t *testing.T) {
+	data := []byte("0123456789abcdefXY")
+	cases := []struct {
+		opts []Option
+		hex  string
+	}{
+		{nil, "30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |"},
+		{[]Option{WithHexDumpGroupSize(4)}, "30 31 32 33  34 35 36 37  38 39 61 62  63 64 65 66  |"},
+		{[]Option{WithHexDumpGroupSize(2)}, "30 31  32 33  34 35  36 37  38 39  61 62  63 64  65 66  |"},
+		{[]Option{WithHexDumpGroupSize(0)}, "30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66  |"},
+		{[]Option{WithHexDumpGroupSize(-1)}, "30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |"},
+	}
+	for _, tc := range cases {
+		out := newDumperT(t, tc.opts...).DumpStr(data)
+		lines := strings.Split(out, "\n")
+		assert.Equal(t, "  00000000  "+tc.hex+" 0123456789abcdef |", lines[1])
+		// The short last line is padded so its ASCII column lines up with the full line.
+		assert.Equal(t, strings.Index(lines[1], "|"), strings.Index(lines[2], "|"))
+	}
+}
+
+func TestHexDumpBaseOffset(t *testing.T) {
+	data := []byte("0123456789abcdefXYZ")
+
+	out := dumpStrT(t, data)
+	assert.Contains(t, out, "\n  00000000  30 31 32 33")
+	assert.Contains(t, out, "\n  00000010  58 59 5a ")
+
+	out = newDumperT(t, WithHexDumpBaseOffset(0x1000)).DumpStr(data)
+	assert.Contains(t, out, "\n  00001000  30 31 32 33")
+	assert.Contains(t, out, "\n  00001010  58 59 5a ")
+	assert.NotContains(t, out, "00000000")
+}
+
 func TestDumpRawMessage(t *testing.T) {
 	type Payload struct {
 		Meta json.RawMessage
@@ -1325,6 +2182,30 @@ func TestIndirectionNilPointer(t *testing.T) {
 	assert.Contains(t, out, "+Embedded => *godump.Embedded(nil)")
 }
 
+func TestDumpSlice(t *testing.T) {
+	type User struct {
+		Name string
+	}
+
+	var buf bytes.Buffer
+	d := newDumperT(t, WithWriter(&buf))
+	d.DumpSlice([]User{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	out := buf.String()
+
+	assert.Contains(t, out, "[0] #godump.User {\n  +Name => \"a\" #string\n}\n")
+	assert.Contains(t, out, "[1] #godump.User {\n  +Name => \"b\" #string\n}\n")
+	assert.Contains(t, out, "[2] #godump.User {\n  +Name => \"c\" #string\n}\n")
+	assert.NotContains(t, out, "#[]godump.User")
+
+	buf.Reset()
+	d.DumpSlice(&[2]int{7, 8})
+	assert.Equal(t, "[0] 7 #int\n[1] 8 #int\n", buf.String())
+
+	buf.Reset()
+	d.DumpSlice(42)
+	assert.Equal(t, "DumpSlice: expected a slice or array, got int\n", buf.String())
+}
+
 func TestDumpJSON(t *testing.T) {
 	t.Run("no arguments", func(t *testing.T) {
 		jsonStr := DumpJSONStr()
@@ -1332,6 +2213,19 @@ func TestDumpJSON(t *testing.T) {
 		assert.JSONEq(t, expected, jsonStr)
 	})
 
+	t.Run("no arguments with custom empty value", func(t *testing.T) {
+		d := NewDumper(WithEmptyJSON("[]"))
+		assert.Equal(t, "[]", d.DumpJSONStr())
+
+		d = NewDumper(WithEmptyJSON("null"))
+		assert.Equal(t, "null", d.DumpJSONStr())
+	})
+
+	t.Run("no arguments ignores invalid empty value", func(t *testing.T) {
+		d := NewDumper(WithEmptyJSON("{not json"))
+		assert.JSONEq(t, `{"error": "DumpJSON called with no arguments"}`, d.DumpJSONStr())
+	})
+
 	t.Run("single struct", func(t *testing.T) {
 		type User struct {
 			Name string `json:"name"`
@@ -1441,6 +2335,42 @@ func TestDumpJSON(t *testing.T) {
 	})
 }
 
+func TestDumpJSONStream(t *testing.T) {
+	type Item struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+
+	samples := [][]any{
+		{},
+		{[]Item{{ID: 1, Tags: []string{"a", "<b>"}}, {ID: 2}}},
+		{[2]int{1, 2}},
+		{[]int{}},
+		{[]int(nil)},
+		{[]byte("raw")},
+		{json.RawMessage(`{"x":1}`)},
+		{map[string]int{"a": 1}},
+		{"one", 2, []int{3}},
+	}
+
+	d := NewDumper()
+	for _, vs := range samples {
+		var buf bytes.Buffer
+		require.NoError(t, d.DumpJSONStream(&buf, vs...))
+		assert.Equal(t, d.DumpJSONStr(vs...)+"\n", buf.String())
+	}
+
+	large := make([]Item, 10000)
+	for i := range large {
+		large[i] = Item{ID: i}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, DumpJSONStream(&buf, large))
+	assert.Equal(t, DumpJSONStr(large)+"\n", buf.String())
+
+	assert.True(t, d.DumpJSONStream(io.Discard, []any{make(chan int)}) != nil)
+}
+
 func TestDisableStringer(t *testing.T) {
 	data := hidden{secret: "not so secret"}
 
@@ -1453,6 +2383,26 @@ func TestDisableStringer(t *testing.T) {
 	assert.Contains(t, v, `-secret => 👻 hidden stringer`)
 }
 
+func TestRawMode(t *testing.T) {
+	type Holder struct {
+		Friendly FriendlyDuration
+		Addr     netip.Addr
+	}
+	v := Holder{Friendly: FriendlyDuration(90 * time.Second), Addr: netip.MustParseAddr("10.0.0.1")}
+
+	out := newDumperT(t, WithRawMode()).DumpStr(v)
+	assert.Contains(t, out, "+Friendly => 90000000000 #godump.FriendlyDuration")
+	assert.NotContains(t, out, "00:01:30")
+	assert.NotContains(t, out, "10.0.0.1")
+	assert.Contains(t, out, "-addr")
+
+	var buf bytes.Buffer
+	newDumperT(t, WithWriter(&buf)).DumpRaw(v.Friendly)
+	assert.Equal(t, "90000000000 #godump.FriendlyDuration\n", buf.String())
+
+	assert.Contains(t, dumpStrT(t, v), "00:01:30")
+}
+
 func TestOnlyFields(t *testing.T) {
 	type User struct {
 		ID       int
@@ -1496,6 +2446,59 @@ func TestFieldFiltersMatchModes(t *testing.T) {
 	assert.Contains(t, out, "+Email")
 }
 
+func TestElideFields(t *testing.T) {
+	type Credentials struct {
+		Password string
+		Token    string
+		User     string
+	}
+	type Account struct {
+		Password    string
+		Credentials Credentials
+		Backup      *Credentials
+		password    string
+	}
+
+	d := newDumperT(t, WithElideFields("Password", "Token"))
+	out := d.DumpStr(Account{
+		Password:    "top",
+		Credentials: Credentials{Password: "nested", Token: "tok", User: "alice"},
+		Backup:      &Credentials{Password: "deep", Token: "tok2", User: "bob"},
+		password:    "lower",
+	})
+
+	assert.NotContains(t, out, "+Password")
+	assert.NotContains(t, out, "Token")
+	assert.NotContains(t, out, "nested")
+	assert.NotContains(t, out, "deep")
+	assert.Contains(t, out, `"alice"`)
+	assert.Contains(t, out, `"bob"`)
+	assert.Contains(t, out, "-password")
+	assert.Contains(t, out, `"lower"`)
+}
+
+func TestStructFieldCount(t *testing.T) {
+	type Profile struct {
+		Age int
+	}
+	type User struct {
+		ID       int
+		Name     string
+		Profile  Profile
+		password string
+	}
+	user := User{ID: 1, Name: "Alice", password: "x"}
+
+	out := newDumperT(t, WithStructFieldCount()).DumpStr(user)
+	assert.Contains(t, out, "#godump.User (4 fields) {")
+	assert.Contains(t, out, "#godump.Profile (1 field) {")
+
+	out = newDumperT(t, WithStructFieldCount(), WithExcludeFields("Name", "password")).DumpStr(user)
+	assert.Contains(t, out, "#godump.User (2 fields) {")
+
+	assert.NotContains(t, dumpStrT(t, user), "fields)")
+}
+
 func TestRedactFields(t *testing.T) {
 	type User struct {
 		ID       int
@@ -1508,6 +2511,41 @@ func TestRedactFields(t *testing.T) {
 	assert.NotContains(t, out, "secret")
 }
 
+func TestRedactRegex(t *testing.T) {
+	type Inner struct {
+		APISecret string
+		Name      string
+	}
+	type Outer struct {
+		SecretKey string
+		Inner     Inner
+		Extra     map[string]string
+	}
+
+	d := newDumperT(t, WithRedactRegex(`(?i)secret`))
+	out := d.DumpStr(Outer{
+		SecretKey: "top",
+		Inner:     Inner{APISecret: "nested", Name: "visible"},
+		Extra:     map[string]string{"client_secret": "mapped", "region": "eu"},
+	})
+
+	assert.Contains(t, out, "+SecretKey   => <redacted> #string")
+	assert.Contains(t, out, "+APISecret => <redacted> #string")
+	assert.Contains(t, out, "client_secret => <redacted> #string")
+	assert.Contains(t, out, `"visible"`)
+	assert.Contains(t, out, `"eu"`)
+	assert.NotContains(t, out, "top")
+	assert.NotContains(t, out, "nested")
+	assert.NotContains(t, out, "mapped")
+}
+
+func TestRedactRegexPanicsOnInvalidPattern(t *testing.T) {
+	defer func() {
+		assert.True(t, recover() != nil)
+	}()
+	WithRedactRegex("(")
+}
+
 func TestRedactSensitiveDefaults(t *testing.T) {
 	type User struct {
 		Password   string
@@ -1649,3 +2687,1251 @@ func TestShouldTruncateAtDepth(t *testing.T) {
 		})
 	}
 }
+
+func TestThousandsSeparator(t *testing.T) {
+	d := newDumperT(t, WithThousandsSeparator(','))
+
+	assert.Contains(t, d.DumpStr(1000000), "1,000,000 #int")
+	assert.Contains(t, d.DumpStr(uint64(1234567)), "1,234,567 #uint64")
+	assert.Contains(t, d.DumpStr(-12345), "-12,345 #int")
+	assert.Contains(t, d.DumpStr(1234567.5), "1,234,567.500000 #float64")
+	assert.Contains(t, d.DumpStr(999), "999 #int")
+
+	assert.Contains(t, dumpStrT(t, 1000000), "1000000 #int")
+}
+
+func TestNumberLocale(t *testing.T) {
+	d := newDumperT(t, WithNumberLocale('.', ','))
+
+	assert.Contains(t, d.DumpStr(1000000.5), "1.000.000,500000 #float64")
+	assert.Contains(t, d.DumpStr(-1234.25), "-1.234,250000 #float64")
+	assert.Contains(t, d.DumpStr(1000000), "1.000.000 #int")
+	assert.Contains(t, d.DumpStr(float32(0.5)), "0,500000 #float32")
+
+	// Decimal control alone leaves digits ungrouped.
+	assert.Contains(t, newDumperT(t, WithNumberLocale(0, ',')).DumpStr(1234.5), "1234,500000 #float64")
+	assert.Contains(t, newDumperT(t, WithNumberLocale(' ', 0)).DumpStr(1234.5), "1 234.500000 #float64")
+}
+
+func TestDumpHTMLDataAttributes(t *testing.T) {
+	type Profile struct {
+		Age int
+	}
+	type User struct {
+		Name    string
+		Profile Profile
+		Tags    []string
+	}
+
+	user := User{Name: "Alice", Profile: Profile{Age: 30}, Tags: []string{"admin"}}
+	out := NewDumper(WithHTMLDataAttributes()).DumpHTML(user)
+
+	assert.Contains(t, out, `data-type="godump.User" data-path="$"`)
+	assert.Contains(t, out, `data-type="string" data-path="$.Name"`)
+	assert.Contains(t, out, `data-type="int" data-path="$.Profile.Age"`)
+	assert.Contains(t, out, `data-path="$.Tags[0]"`)
+	assert.Contains(t, out, `<span style="color:`)
+
+	assert.NotContains(t, NewDumper().DumpHTML(user), "data-path")
+	assert.NotContains(t, newDumperT(t, WithHTMLDataAttributes()).DumpStr(user), "data-path")
+}
+
+func TestMapStructKeys(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+	type Tagged struct {
+		Name string
+		at   *Point
+	}
+
+	out := dumpStrT(t, map[Point]string{{X: 1, Y: 2}: "a"})
+	assert.Contains(t, out, `Point{X:1, Y:2} => "a" #string`)
+	assert.NotContains(t, out, "{1 2}")
+
+	out = dumpStrT(t, map[Tagged]int{{Name: "n"}: 1})
+	assert.Contains(t, out, `Tagged{Name:"n", at:nil} => 1 #int`)
+
+	out = dumpStrT(t, map[time.Time]int{time.Unix(0, 0).UTC(): 1})
+	assert.Contains(t, out, "1970-01-01 00:00:00 +0000 UTC => 1 #int")
+}
+
+type kPtr struct {
+	P *kPtr
+}
+
+func TestMapStructKeysCycleAndHiddenFields(t *testing.T) {
+	k := &kPtr{}
+	k.P = k
+	out := dumpStrT(t, map[kPtr]int{*k: 1})
+	assert.Contains(t, out, "kPtr{P:&kPtr{P:&godump.kPtr{…}}} => 1 #int")
+
+	type Creds struct {
+		User     string
+		Password string
+		Token    string `godump:"redact"`
+		internal string `godump:"-"`
+	}
+	out = newDumperT(t, WithRedactFields("Password")).DumpStr(map[Creds]int{{User: "ada", Password: "pw", Token: "tok", internal: "x"}: 1})
+	assert.Contains(t, out, `Creds{User:"ada", Password:<redacted>, Token:<redacted>} => 1 #int`)
+	assert.NotContains(t, out, "tok")
+	assert.NotContains(t, out, "internal")
+}
+
+func TestDumpStrPooledBuffersProduceIdenticalOutput(t *testing.T) {
+	type Item struct {
+		ID   int
+		Tags []string
+	}
+	v := map[string]Item{"a": {ID: 1, Tags: []string{"x", "y"}}}
+
+	d := newDumperT(t)
+	want := d.DumpStr(v)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, want, d.DumpStr(v))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.Equal(t, want, d.DumpStr(v))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPutDumpBufferDropsOversizedBuffers(t *testing.T) {
+	buf := getDumpBuffer()
+	buf.out.Grow(maxPooledBufferSize + 1)
+	putDumpBuffer(buf)
+
+	next := getDumpBuffer()
+	defer putDumpBuffer(next)
+	assert.Equal(t, 0, next.out.Len())
+}
+
+func BenchmarkDumpStr(b *testing.B) {
+	type Item struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+	v := []Item{{ID: 1, Name: "one", Tags: []string{"a", "b"}}, {ID: 2, Name: "two"}}
+	d := NewDumper(WithoutColor())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = d.DumpStr(v)
+	}
+}
+
+type longGenericEnvelope[T any] struct {
+	Payload T
+}
+
+func TestTypeAlias(t *testing.T) {
+	type Item struct {
+		ID int
+	}
+	v := longGenericEnvelope[map[string][]Item]{Payload: map[string][]Item{"a": {{ID: 1}}}}
+
+	d := newDumperT(t, WithTypeAlias(reflect.TypeOf(v), "Envelope"))
+	out := d.DumpStr(v)
+	assert.True(t, strings.HasPrefix(out, "#Envelope {"))
+	assert.NotContains(t, out, "longGenericEnvelope")
+
+	out = d.DumpStr([]*longGenericEnvelope[map[string][]Item]{&v})
+	assert.Contains(t, out, "#[]*Envelope [")
+
+	plain := dumpStrT(t, v)
+	assert.Contains(t, plain, "#godump.longGenericEnvelope[")
+}
+
+func TestCallbackOnTruncate(t *testing.T) {
+	type call struct {
+		kind, path string
+		total      int
+	}
+	var calls []call
+	d := newDumperT(t,
+		WithMaxItems(2),
+		WithMaxStringLen(3),
+		WithCallbackOnTruncate(func(kind, path string, total int) {
+			calls = append(calls, call{kind, path, total})
+		}),
+	)
+
+	type Payload struct {
+		IDs  []int
+		Note string
+	}
+	d.DumpStr(Payload{IDs: []int{1, 2, 3, 4}, Note: "abcdef"})
+
+	require.True(t, len(calls) == 2)
+	assert.Equal(t, call{"slice", "$.IDs", 4}, calls[0])
+	assert.Equal(t, call{"string", "$.Note", 6}, calls[1])
+
+	calls = nil
+	d.DumpStr([]string{"ab"})
+	assert.Equal(t, 0, len(calls))
+}
+
+func TestOnlyNonDefault(t *testing.T) {
+	type TLS struct {
+		Cert string
+		Key  string
+	}
+	type Config struct {
+		Host    string
+		Port    int
+		Debug   bool
+		Ratio   float64
+		Plugins []string
+		Labels  map[string]string
+		TLS     *TLS
+		Limits  TLS
+		Tags    []string
+	}
+	cfg := Config{
+		Port:    8080,
+		Plugins: []string{},
+		TLS:     &TLS{},
+		Limits:  TLS{Key: "k"},
+		Tags:    []string{"x"},
+	}
+
+	out := newDumperT(t, WithOnlyNonDefault(), WithStructFieldCount()).DumpStr(cfg)
+	assert.Contains(t, out, "#godump.Config (3 fields) {")
+	assert.Contains(t, out, "+Port   => 8080 #int")
+	assert.Contains(t, out, `+Key  => "k" #string`)
+	assert.Contains(t, out, "+Tags => #[]string [")
+	for _, hidden := range []string{"+Host", "+Debug", "+Ratio", "+Plugins", "+Labels", "+TLS", "+Cert"} {
+		assert.NotContains(t, out, hidden)
+	}
+
+	full := dumpStrT(t, cfg)
+	assert.Contains(t, full, "+Host")
+	assert.Contains(t, full, "+TLS")
+}
+
+func TestOnlyNonDefaultCyclicValue(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	n := &Node{}
+	n.Next = n
+
+	out := newDumperT(t, WithOnlyNonDefault()).DumpStr(n)
+	assert.Contains(t, out, "+Next => ")
+	assert.NotContains(t, out, "+Name")
+
+	// A cycle through an interface is caught by its pointer as well.
+	type Box struct {
+		V any
+	}
+	b := &Box{}
+	b.V = b
+	assert.Contains(t, newDumperT(t, WithOnlyNonDefault()).DumpStr(b), "+V => ")
+}
+
+func TestMaxWidth(t *testing.T) {
+	type Request struct {
+		URL     string
+		Headers map[string]string
+		Body    []string
+	}
+	v := Request{
+		URL:     "https://example.com/" + strings.Repeat("segment/", 20),
+		Headers: map[string]string{"Authorization": "Bearer " + strings.Repeat("t", 80)},
+		Body:    []string{strings.Repeat("lorem ipsum ", 15)},
+	}
+
+	d := NewDumper(WithMaxWidth(50), WithMaxStringLen(1000))
+	d.colorizer = colorizeANSI
+	out := d.DumpStr(v)
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		assert.True(t, utf8.RuneCountInString(stripANSI(line)) <= 50, line)
+	}
+	assert.Contains(t, stripANSI(out), "segment/")
+	assert.Equal(t,
+		strings.ReplaceAll(stripANSI(NewDumper(WithoutColor(), WithMaxStringLen(1000)).DumpStr(v.URL)), "\n", ""),
+		strings.ReplaceAll(strings.ReplaceAll(stripANSI(newDumperT(t, WithMaxWidth(30), WithMaxStringLen(1000)).DumpStr(v.URL)), "\n  ", ""), "\n", ""),
+	)
+
+	short := newDumperT(t, WithMaxWidth(50)).DumpStr([]int{1})
+	assert.Equal(t, dumpStrT(t, []int{1}), short)
+}
+
+func TestLazyFormatter(t *testing.T) {
+	type Expensive struct {
+		Rows []int
+	}
+	type Level struct {
+		Child *Level
+		Data  Expensive
+	}
+
+	calls := 0
+	opt := WithLazyFormatter(reflect.TypeOf(Expensive{}), func(v reflect.Value) string {
+		calls++
+		return fmt.Sprintf("Expensive(%d rows)", v.FieldByName("Rows").Len())
+	})
+
+	out := newDumperT(t, opt).DumpStr(Level{Data: Expensive{Rows: []int{1, 2}}})
+	assert.Contains(t, out, "+Data  => Expensive(2 rows) #godump.Expensive")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	deep := &Level{Child: &Level{Child: &Level{Data: Expensive{Rows: []int{1}}}}}
+	out = newDumperT(t, opt, WithMaxDepth(1)).DumpStr(deep)
+	assert.Contains(t, out, "... (max depth)")
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	newDumperT(t, opt, WithMaxItems(1)).DumpStr([]Expensive{{}, {}, {}})
+	assert.Equal(t, 1, calls)
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	type OrderID uint32
+	type Order struct {
+		ID      OrderID
+		id      OrderID
+		Created time.Time
+	}
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	order := Order{ID: 0xdeadbeef, id: 0x01020304, Created: created}
+
+	d := newDumperT(t, WithTimeFormat(time.RFC3339))
+	other := d.clone()
+	d.RegisterFormatter(reflect.TypeOf(OrderID(0)), func(v reflect.Value) string {
+		return fmt.Sprintf("order-%08x", uint32(v.Interface().(OrderID)))
+	})
+	d.RegisterFormatter(reflect.TypeOf(time.Time{}), func(v reflect.Value) string {
+		return v.Interface().(time.Time).Format("2006-01-02")
+	})
+
+	out := d.DumpStr(order)
+	assert.Contains(t, out, "+ID      => order-deadbeef #godump.OrderID")
+	assert.Contains(t, out, "-id      => order-01020304 #godump.OrderID")
+	assert.Contains(t, out, "+Created => 2024-05-06 #time.Time")
+	assert.NotContains(t, out, "2024-05-06T07:08:09Z")
+
+	// The registry is per dumper: neither an earlier clone nor a fresh dumper sees it.
+	assert.NotContains(t, other.DumpStr(order), "order-")
+	assert.Contains(t, other.DumpStr(order), "2024-05-06T07:08:09Z")
+	assert.NotContains(t, dumpStrT(t, order), "order-")
+}
+
+func TestComparableKeyDedup(t *testing.T) {
+	type Entry struct {
+		ID int
+	}
+	shared := &Entry{ID: 1}
+	cache := map[string]*Entry{"a": shared, "b": shared, "c": {ID: 2}}
+
+	out := newDumperT(t, WithComparableKeyDedup()).DumpStr(cache)
+	assert.Contains(t, out, "   a => #*godump.Entry {\n    +ID => 1 #int\n  }\n")
+	assert.Contains(t, out, "   b => ↩︎ &1\n")
+	assert.Contains(t, out, "   c => #*godump.Entry {\n    +ID => 2 #int\n  }\n")
+
+	plain := dumpStrT(t, cache)
+	assert.NotContains(t, plain, "↩︎")
+
+	// Pointers held in interface values are dumped once, not turned into a ref to themselves.
+	anyCache := map[string]any{"a": shared, "b": shared}
+	out = newDumperT(t, WithComparableKeyDedup()).DumpStr(anyCache)
+	assert.Contains(t, out, "   a => #*godump.Entry {\n    +ID => 1 #int\n  }\n")
+	assert.Contains(t, out, "   b => ↩︎ &1\n")
+	assert.Equal(t, 1, strings.Count(out, "↩︎"))
+}
+
+func TestRecursiveTypeWithoutCycle(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+	var head *Node
+	for i := 10; i >= 1; i-- {
+		head = &Node{Value: i, Next: head}
+	}
+
+	out := newDumperT(t, WithMaxDepth(20)).DumpStr(head)
+	assert.NotContains(t, out, "↩︎")
+	for i := 1; i <= 10; i++ {
+		assert.Contains(t, out, fmt.Sprintf("=> %d #int\n", i))
+	}
+	assert.Contains(t, out, "=> *godump.Node(nil)")
+
+	type Inner struct {
+		ID int
+	}
+	type Outer struct {
+		First Inner
+	}
+	type Holder struct {
+		Outer *Outer
+		Inner *Inner
+		Empty []*struct{}
+	}
+	o := &Outer{First: Inner{ID: 1}}
+	out = dumpStrT(t, Holder{Outer: o, Inner: &o.First, Empty: []*struct{}{{}, {}}})
+	assert.NotContains(t, out, "↩︎")
+	assert.Contains(t, out, "+Inner => #*godump.Inner {")
+}
+
+func TestCustomBoolStrings(t *testing.T) {
+	type Flags struct {
+		Enabled bool
+		Beta    bool
+	}
+
+	out := newDumperT(t, WithCustomBoolStrings("✓", "✗")).DumpStr(Flags{Enabled: true})
+	assert.Contains(t, out, "+Enabled => ✓ #bool")
+	assert.Contains(t, out, "+Beta    => ✗ #bool")
+
+	d := NewDumper(WithCustomBoolStrings("yes", "no"))
+	d.colorizer = colorizeANSI
+	out = d.DumpStr([]bool{true, false})
+	assert.Contains(t, out, colorYellow+"yes"+colorReset)
+	assert.Contains(t, out, colorGray+"no"+colorReset)
+
+	assert.Contains(t, dumpStrT(t, true), "true #bool")
+}
+
+type methodsGreeter struct {
+	Name string
+}
+
+func (g methodsGreeter) Greet(greeting string, extra ...int) string { return greeting + g.Name }
+
+func (g *methodsGreeter) Rename(name string) error {
+	g.Name = name
+	return nil
+}
+
+func TestShowMethods(t *testing.T) {
+	out := newDumperT(t, WithShowMethods()).DumpStr(&methodsGreeter{Name: "Ada"})
+	assert.Contains(t, out, "#*godump.methodsGreeter {\n  +Name => \"Ada\" #string\n  methods:\n")
+	assert.Contains(t, out, "    Greet(string, ...int) string\n")
+	assert.Contains(t, out, "    Rename(string) error\n")
+
+	out = newDumperT(t, WithShowMethods()).DumpStr(methodsGreeter{Name: "Ada"})
+	assert.Contains(t, out, "Greet(string, ...int) string")
+	assert.NotContains(t, out, "Rename")
+
+	out = newDumperT(t, WithShowMethods(), WithMaxItems(1)).DumpStr(&methodsGreeter{})
+	assert.Contains(t, out, "Greet(")
+	assert.NotContains(t, out, "Rename")
+	assert.Contains(t, out, "... (truncated)")
+
+	assert.NotContains(t, dumpStrT(t, &methodsGreeter{}), "methods:")
+}
+
+func TestDepthNumbers(t *testing.T) {
+	type Inner struct {
+		Tags []string
+	}
+	type Outer struct {
+		Inner Inner
+	}
+
+	out := newDumperT(t, WithDepthNumbers()).DumpStr(Outer{Inner: Inner{Tags: []string{"a"}}})
+	assert.Equal(t, "[0] #godump.Outer {\n"+
+		"[1]   +Inner  => #godump.Inner {\n"+
+		"[2]     +Tags => #[]string [\n"+
+		"[3]       0 => \"a\" #string\n"+
+		"[2]     ]\n"+
+		"[1]   }\n"+
+		"[0] }\n", out)
+
+	assert.NotContains(t, dumpStrT(t, Outer{}), "[0]")
+}
+
+type trimPair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func TestTrimLongTypeParams(t *testing.T) {
+	type VeryLongTypeNameForTesting struct{}
+	v := trimPair[string, map[string][]VeryLongTypeNameForTesting]{Key: "a"}
+
+	out := newDumperT(t, WithTrimLongTypeParams(10)).DumpStr(v)
+	assert.True(t, strings.HasPrefix(out, "#godump.trimPair[string, …] {"), out)
+
+	out = newDumperT(t, WithTrimLongTypeParams(3)).DumpStr(&v)
+	assert.True(t, strings.HasPrefix(out, "#*godump.trimPair[…] {"), out)
+
+	out = newDumperT(t, WithTrimLongTypeParams(500)).DumpStr(v)
+	assert.Contains(t, out, "VeryLongTypeNameForTesting")
+
+	assert.Contains(t, dumpStrT(t, v), "VeryLongTypeNameForTesting")
+	assert.Equal(t, "pkg.Plain", trimTypeParams("pkg.Plain", 1))
+	assert.Equal(t, "pkg.Box[…]", trimTypeParams("pkg.Box[map[string][]int]", 3))
+	assert.Equal(t, "pkg.Pair[int, …]", trimTypeParams("pkg.Pair[int, func([]int) []string]", 8))
+	assert.Equal(t, "func([]int) pkg.Box[…]", trimTypeParams("func([]int) pkg.Box[string]", 3))
+	assert.Equal(t, "[]int", trimTypeParams("[]int", 1))
+
+	fn := func([]int) trimPair[string, map[string][]VeryLongTypeNameForTesting] { return v }
+	out = newDumperT(t, WithTrimLongTypeParams(3)).DumpStr(fn)
+	assert.Contains(t, out, "#func([]int) godump.trimPair[string,map[string][]")
+	assert.NotContains(t, out, "…")
+}
+
+func TestTextTaggedByteSlice(t *testing.T) {
+	type JSON []byte
+	type Message struct {
+		Body    []byte  `godump:"text"`
+		Payload JSON    `json:"payload" godump:"text"`
+		Digest  [4]byte `godump:"text"`
+		Raw     []byte
+		Empty   []byte `godump:"text"`
+	}
+	m := Message{
+		Body:    []byte("line1\nline2"),
+		Payload: JSON(`{"ok":true}`),
+		Digest:  [4]byte{'a', 'b', 'c', 'd'},
+		Raw:     []byte("raw"),
+	}
+
+	out := dumpStrT(t, m)
+	assert.Contains(t, out, `=> "line1\nline2" #[]uint8`)
+	assert.Contains(t, out, `=> "{"ok":true}" #[]uint8`)
+	assert.Contains(t, out, `=> "abcd" #[4]uint8`)
+	assert.Contains(t, out, "=> []uint8(nil)")
+	assert.NotContains(t, out, `"raw"`)
+}
+
+type failingWriter struct{ err error }
+
+func (f failingWriter) Write(p []byte) (int, error) { return 0, f.err }
+
+func TestDumpNReturnsBytesWritten(t *testing.T) {
+	var b strings.Builder
+	d := newDumperT(t)
+	n, err := d.DumpN(&b, map[string]int{"a": 1}, "x")
+	assert.NoError(t, err)
+	assert.Equal(t, b.Len(), n)
+	assert.Equal(t, d.DumpStr(map[string]int{"a": 1}, "x"), b.String())
+
+	want := errors.New("disk full")
+	n, err = d.DumpN(failingWriter{err: want}, 1)
+	assert.Equal(t, 0, n)
+	assert.True(t, errors.Is(err, want))
+}
+
+func TestUnexportedCompositeFieldsRenderContents(t *testing.T) {
+	type point struct{ x int }
+	type holder struct {
+		counts map[string]int
+		items  []point
+		ref    *point
+	}
+	// Map values are not addressable, so the unexported fields can only be read
+	// through the values themselves.
+	v := map[string]holder{
+		"h": {counts: map[string]int{"a": 1, "b": 2}, items: []point{{x: 3}}, ref: &point{x: 4}},
+	}
+
+	out := stripANSI(dumpStrT(t, v))
+	assert.NotContains(t, out, "<unexported>")
+	assert.Contains(t, out, "a => 1 #int")
+	assert.Contains(t, out, "b => 2 #int")
+	assert.Contains(t, out, "-x => 3 #int")
+	assert.Contains(t, out, "-x => 4 #int")
+}
+
+func TestWithRenderZeroPointersAsNil(t *testing.T) {
+	type Limits struct{ Max int }
+	type Config struct {
+		Empty  *struct{}
+		Zero   *Limits
+		Set    *Limits
+		Absent *Limits
+	}
+	v := Config{Empty: &struct{}{}, Zero: &Limits{}, Set: &Limits{Max: 3}}
+
+	out := stripANSI(newDumperT(t, WithRenderZeroPointersAsNil()).DumpStr(v))
+	assert.Contains(t, out, "=> *struct {}(zero)")
+	assert.Contains(t, out, "=> *godump.Limits(zero)")
+	assert.Contains(t, out, "+Max => 3 #int")
+	assert.Contains(t, out, "=> *godump.Limits(nil)")
+
+	out = stripANSI(newDumperT(t).DumpStr(v))
+	assert.NotContains(t, out, "(zero)")
+	assert.Contains(t, out, "+Max => 0 #int")
+}
+
+func TestWithMaxMapItemsPerMap(t *testing.T) {
+	type Pair struct {
+		Left  map[string]int
+		Right map[string]int
+		List  []int
+	}
+	v := Pair{
+		Left:  map[string]int{"a": 1, "b": 2, "c": 3},
+		Right: map[string]int{"x": 1, "y": 2, "z": 3},
+		List:  []int{1, 2, 3},
+	}
+
+	out := stripANSI(newDumperT(t, WithMaxMapItems(2)).DumpStr(v))
+	assert.Equal(t, 2, strings.Count(out, "... (1 more truncated)"))
+	assert.Contains(t, out, "a => 1 #int")
+	assert.Contains(t, out, "b => 2 #int")
+	assert.NotContains(t, out, "c => 3")
+	assert.Contains(t, out, "x => 1 #int")
+	assert.Contains(t, out, "y => 2 #int")
+	assert.NotContains(t, out, "z => 3")
+	assert.Contains(t, out, "2 => 3 #int")
+
+	// The truncation note sits on its own line, so the closing brace isn't glued to it.
+	assert.Contains(t, out, "+Left => #map[string]int {\n"+
+		"     a => 1 #int\n"+
+		"     b => 2 #int\n"+
+		"    ... (1 more truncated)\n"+
+		"  }\n")
+	assert.NotContains(t, out, "truncated)}")
+
+	// Without WithMaxMapItems, maps share WithMaxItems but each still gets its own budget.
+	out = stripANSI(newDumperT(t, WithMaxItems(2)).DumpStr(v))
+	assert.Equal(t, 3, strings.Count(out, "... (1 more truncated)"))
+}
+
+func TestWithInterfaceMethodSets(t *testing.T) {
+	type Sink struct {
+		Out   io.Writer
+		RW    io.ReadWriter
+		Any   any
+		Ready io.Writer
+	}
+	v := Sink{Ready: io.Discard}
+
+	out := stripANSI(newDumperT(t, WithInterfaceMethodSets()).DumpStr(v))
+	assert.Contains(t, out, "io.Writer(nil) {Write}")
+	assert.Contains(t, out, "io.ReadWriter(nil) {Read, Write}")
+	assert.Contains(t, out, "interface {}(nil)\n")
+
+	var w io.Writer
+	out = stripANSI(newDumperT(t, WithInterfaceMethodSets()).DumpStr(&w))
+	assert.Contains(t, out, "io.Writer(nil) {Write}")
+
+	out = stripANSI(newDumperT(t).DumpStr(v))
+	assert.NotContains(t, out, "{Write}")
+}
+
+func TestIndentString(t *testing.T) {
+	for _, depth := range []int{0, 1, 5, 64, 65, 200} {
+		assert.Equal(t, strings.Repeat(" ", depth*defaultIndentWidth), NewDumper().indentString(depth))
+		assert.Equal(t, strings.Repeat(" ", depth*4), NewDumper(WithIndentWidth(4)).indentString(depth))
+	}
+}
+
+func BenchmarkDumpStrDeep(b *testing.B) {
+	type Node struct {
+		Name     string
+		Values   []int
+		Children []*Node
+	}
+	var build func(depth int) *Node
+	build = func(depth int) *Node {
+		n := &Node{Name: "node", Values: []int{1, 2, 3}}
+		if depth > 0 {
+			for i := 0; i < 4; i++ {
+				n.Children = append(n.Children, build(depth-1))
+			}
+		}
+		return n
+	}
+	v := build(5)
+	d := NewDumper(WithoutColor(), WithMaxDepth(20))
+
+	b.Run("DumpStr", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = d.DumpStr(v)
+		}
+	})
+
+	// indentPrint runs once per output line; its allocs/op should stay at 0.
+	b.Run("indentPrint", func(b *testing.B) {
+		tw := tabwriter.NewWriter(io.Discard, 0, 0, 1, ' ', 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d.indentPrint(tw, i%12, "")
+		}
+	})
+}
+
+func TestIndentPrintDoesNotAllocate(t *testing.T) {
+	d := NewDumper(WithoutColor())
+	var buf bytes.Buffer
+	buf.Grow(1 << 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		d.indentPrint(&buf, 12, "}")
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, strings.Repeat(" ", 24)+"}", buf.String())
+}
+
+func TestWithHeadless(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		Name    string
+		Address Address
+	}
+	v := User{Name: "Ada", Address: Address{City: "London"}}
+
+	out := stripANSI(newDumperT(t, WithHeadless()).DumpStr(v))
+	assert.NotContains(t, out, "#godump.User {")
+	assert.True(t, strings.HasPrefix(out, "+Name"))
+	assert.Contains(t, out, "\n+Address => #godump.Address {\n  +City  => \"London\" #string\n}\n")
+
+	out = stripANSI(newDumperT(t, WithHeadless()).DumpStr([]int{1, 2}))
+	assert.Equal(t, "0 => 1 #int\n1 => 2 #int\n", out)
+
+	// Scalars and multiple values are left alone.
+	assert.Equal(t, "42 #int\n", stripANSI(newDumperT(t, WithHeadless()).DumpStr(42)))
+	out = stripANSI(newDumperT(t, WithHeadless()).DumpStr(v, v))
+	assert.Contains(t, out, "#godump.User {")
+
+	// Depth markers are renumbered and the closing line goes with the header.
+	out = newDumperT(t, WithHeadless(), WithDepthNumbers()).DumpStr(v)
+	assert.Equal(t, "[0] +Name    => \"Ada\" #string\n"+
+		"[0] +Address => #godump.Address {\n"+
+		"[1]   +City  => \"London\" #string\n"+
+		"[0] }\n", out)
+
+	d := newDumperT(t, WithHeadless(), WithDepthNumbers())
+	d.colorizer = colorizeANSI
+	out = stripANSI(d.DumpStr([]int{1, 2}))
+	assert.Equal(t, "[0] 0 => 1 #int\n[0] 1 => 2 #int\n", out)
+}
+
+type limitDeep struct {
+	Name string
+	Next *limitDeep
+}
+
+type limitShallow struct {
+	Name string
+	Next *limitShallow
+}
+
+func TestWithRecursionLimitCallback(t *testing.T) {
+	type Root struct {
+		Deep    *limitDeep
+		Shallow *limitShallow
+	}
+	v := Root{
+		Deep:    &limitDeep{Name: "d1", Next: &limitDeep{Name: "d2", Next: &limitDeep{Name: "d3", Next: &limitDeep{Name: "d4"}}}},
+		Shallow: &limitShallow{Name: "s1", Next: &limitShallow{Name: "s2", Next: &limitShallow{Name: "s3"}}},
+	}
+
+	var paths []string
+	d := newDumperT(t, WithMaxDepth(2), WithRecursionLimitCallback(func(path string, typ reflect.Type) int {
+		paths = append(paths, path)
+		if typ == reflect.TypeOf(&limitDeep{}) {
+			return 1
+		}
+		return 0
+	}))
+	out := d.DumpStr(v)
+
+	assert.Contains(t, out, `"d3"`)
+	assert.Contains(t, out, `"d4"`)
+	assert.Contains(t, out, `"s2"`)
+	assert.NotContains(t, out, `"s3"`)
+	assert.Contains(t, out, "... (max depth)")
+	assert.Contains(t, strings.Join(paths, " "), "$.Shallow.Next.Next")
+
+	// Without the callback both chains stop at the same depth.
+	out = newDumperT(t, WithMaxDepth(2)).DumpStr(v)
+	assert.NotContains(t, out, `"d3"`)
+	assert.NotContains(t, out, `"s3"`)
+}
+
+type jsonMoney struct {
+	cents    int64
+	currency string
+}
+
+func (m jsonMoney) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"amount":"%d.%02d","currency":%q}`, m.cents/100, m.cents%100, m.currency)), nil
+}
+
+type jsonBroken struct{ ID int }
+
+func (jsonBroken) MarshalJSON() ([]byte, error) { return nil, errors.New("boom") }
+
+type jsonPtrOnly struct{ ID int }
+
+func (p *jsonPtrOnly) MarshalJSON() ([]byte, error) { return []byte(`"ptr"`), nil }
+
+func TestWithJSONMarshalerRendering(t *testing.T) {
+	type Order struct {
+		Total  jsonMoney
+		Broken jsonBroken
+		Ptr    jsonPtrOnly
+	}
+	v := Order{Total: jsonMoney{cents: 1999, currency: "EUR"}, Broken: jsonBroken{ID: 7}}
+
+	out := newDumperT(t, WithJSONMarshalerRendering()).DumpStr(v)
+	assert.Contains(t, out, "+Total => {\n    \"amount\": \"19.99\",\n    \"currency\": \"EUR\"\n  } #godump.jsonMoney")
+	assert.Contains(t, out, "=> 7 #int")
+	assert.Contains(t, out, `=> "ptr" #godump.jsonPtrOnly`)
+	assert.NotContains(t, out, "cents")
+
+	out = newDumperT(t).DumpStr(v)
+	assert.Contains(t, out, "-cents")
+}
+
+func TestWithObfuscateValues(t *testing.T) {
+	type Account struct {
+		Email   string
+		Balance float64
+		Visits  int
+		Tags    map[string]string
+		Since   time.Duration
+		Active  bool
+	}
+	v := Account{
+		Email:   "ada@example.com",
+		Balance: -1234.5,
+		Visits:  42,
+		Tags:    map[string]string{"tier": "gold"},
+		Since:   90 * time.Minute,
+		Active:  true,
+	}
+
+	d := newDumperT(t, WithObfuscateValues())
+	out := d.DumpStr(v)
+	assert.Contains(t, out, `=> "xxxxxxxxxxxxxxx" #string`)
+	assert.Contains(t, out, "=> -9999.999999 #float64")
+	assert.Contains(t, out, "=> 99 #int")
+	assert.Contains(t, out, `tier => "xxxx" #string`)
+	assert.Contains(t, out, "=> 9x99x9x #time.Duration")
+	assert.Contains(t, out, "=> true")
+	assert.NotContains(t, out, "ada")
+	assert.NotContains(t, out, "gold")
+	assert.NotContains(t, out, "42")
+
+	// Structure and field names are preserved, and output is repeatable.
+	plain := newDumperT(t).DumpStr(v)
+	assert.Equal(t, strings.Count(plain, "\n"), strings.Count(out, "\n"))
+	assert.Equal(t, out, d.DumpStr(v))
+}
+
+func TestWithObfuscateValuesFormattedTypes(t *testing.T) {
+	type Record struct {
+		At    time.Time
+		Z     complex128
+		Addr  netip.Addr
+		Raw   []byte
+		Note  []byte `godump:"text"`
+		Extra json.RawMessage
+	}
+	v := Record{
+		At:    time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		Z:     complex(1.5, -2),
+		Addr:  netip.MustParseAddr("10.1.2.3"),
+		Raw:   []byte("secret"),
+		Note:  []byte("hush"),
+		Extra: json.RawMessage(`{"pin":1234}`),
+	}
+
+	d := newDumperT(t, WithObfuscateValues(), WithTimeFormat("Jan 2, 2006"), WithJSONMarshalerRendering())
+	out := d.DumpStr(v)
+	assert.Contains(t, out, "=> xxx 9, 9999 #time.Time")
+	assert.Contains(t, out, "=> (9.9-9i) #complex128")
+	assert.Contains(t, out, "=> 99.9.9.9 #netip.Addr")
+	assert.Contains(t, out, "78 78 78 78 78 78")
+	assert.Contains(t, out, `=> "xxxx" #[]uint8`)
+	assert.Contains(t, out, `"xxx": 9999`)
+	for _, leak := range []string{"Mar", "2024", "1.5", "10.1", "secret", "73 65", "hush", "pin", "1234"} {
+		assert.NotContains(t, out, leak)
+	}
+}
+
+func TestWithRenderFunc(t *testing.T) {
+	type Secret struct {
+		Token string
+	}
+	type Config struct {
+		Name   string
+		Port   int
+		Secret Secret
+		Tags   []string
+	}
+	v := Config{Name: "api", Port: 8080, Secret: Secret{Token: "t0k3n"}, Tags: []string{"a"}}
+
+	var depths []int
+	d := newDumperT(t, WithRenderFunc(func(v reflect.Value, indent int) (string, bool) {
+		switch {
+		case v.Kind() == reflect.String:
+			depths = append(depths, indent)
+			return "<str>", true
+		case v.Type() == reflect.TypeOf(Secret{}):
+			return "<hidden>", true
+		}
+		return "", false
+	}))
+	out := d.DumpStr(v)
+
+	assert.Contains(t, out, "+Name   => <str>")
+	assert.Contains(t, out, "0 => <str>")
+	assert.Contains(t, out, "+Secret => <hidden>")
+	assert.Contains(t, out, "+Port   => 8080 #int")
+	assert.NotContains(t, out, "Token")
+	assert.Equal(t, "1 2", strings.Trim(fmt.Sprint(depths), "[]"))
+}
+
+func TestSortedMapKeys(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		out := dumpStrT(t, map[string]int{"b": 2, "a": 1, "c": 3})
+		assert.Equal(t, "#map[string]int {\n   a => 1 #int\n   b => 2 #int\n   c => 3 #int\n}\n", out)
+	}
+
+	out := dumpStrT(t, map[int]string{10: "ten", 9: "nine", -1: "neg"})
+	assert.True(t, strings.Index(out, "neg") < strings.Index(out, "nine"))
+	assert.True(t, strings.Index(out, "nine") < strings.Index(out, "ten"))
+
+	out = dumpStrT(t, map[float64]bool{2.5: true, 0.5: false})
+	assert.True(t, strings.Index(out, "0.5") < strings.Index(out, "2.5"))
+
+	type Key struct{ A, B int }
+	out = dumpStrT(t, map[Key]int{{A: 2}: 2, {A: 1}: 1})
+	assert.True(t, strings.Index(out, "A:1") < strings.Index(out, "A:2"))
+
+	unsorted := newDumperT(t, WithSortedMapKeys(false))
+	assert.False(t, unsorted.sortMapKeys)
+	assert.Contains(t, unsorted.DumpStr(map[string]int{"b": 2, "a": 1}), "a => 1 #int")
+}
+
+func TestWithAutoFlush(t *testing.T) {
+	var out, extra bytes.Buffer
+	w := bufio.NewWriter(&out)
+	ew := bufio.NewWriter(&extra)
+
+	d := NewDumper(WithWriter(w), WithExtraWriter(ew), WithoutColor(), WithAutoFlush())
+	d.Dump("hello")
+	assert.Equal(t, `"hello" #string`+"\n", out.String())
+	assert.Equal(t, out.String(), extra.String())
+
+	d.DumpJSON(1)
+	assert.Contains(t, out.String(), "1\n")
+
+	var buffered bytes.Buffer
+	bw := bufio.NewWriter(&buffered)
+	NewDumper(WithWriter(bw), WithoutColor()).Dump("hello")
+	assert.Equal(t, 0, buffered.Len())
+	require.NoError(t, bw.Flush())
+	assert.Equal(t, `"hello" #string`+"\n", buffered.String())
+}
+
+func TestWithIndentWidth(t *testing.T) {
+	type Inner struct {
+		ID int
+	}
+	type Outer struct {
+		Inner Inner
+	}
+
+	out := newDumperT(t, WithIndentWidth(4)).DumpStr(Outer{Inner: Inner{ID: 1}})
+	assert.Equal(t, "#godump.Outer {\n    +Inner  => #godump.Inner {\n        +ID => 1 #int\n    }\n}\n", out)
+
+	js := NewDumper(WithIndentWidth(4)).DumpJSONStr(map[string][]int{"a": {1}})
+	assert.Equal(t, "{\n    \"a\": [\n        1\n    ]\n}", js)
+
+	var b strings.Builder
+	require.NoError(t, NewDumper(WithIndentWidth(4)).DumpJSONStream(&b, []int{1, 2}))
+	assert.Equal(t, "[\n    1,\n    2\n]\n", b.String())
+
+	// Negative widths are ignored.
+	assert.Equal(t, defaultIndentWidth, NewDumper(WithIndentWidth(-1)).indentWidth)
+}
+
+type chainUser struct {
+	Name string
+}
+
+func TestPointerInterfaceChains(t *testing.T) {
+	var inner any = &chainUser{Name: "ada"}
+	outer := &inner
+
+	out := newDumperT(t).DumpStr(outer)
+	assert.Equal(t, "#*godump.chainUser {\n  +Name => \"ada\" #string\n}\n", out)
+
+	out = newDumperT(t, WithMarkPointers()).DumpStr(outer)
+	assert.True(t, strings.HasPrefix(out, "*(interface {}) *#*godump.chainUser {"))
+
+	var wrapped any = outer
+	out = newDumperT(t, WithMarkPointers()).DumpStr(&wrapped)
+	assert.True(t, strings.HasPrefix(out, "*(interface {}) *(interface {}) *#*godump.chainUser {"))
+	assert.Contains(t, out, `+Name => "ada" #string`)
+
+	// A self-referencing interface terminates instead of recursing forever.
+	var self any
+	self = &self
+	out = newDumperT(t).DumpStr(&self)
+	assert.Contains(t, out, "↩︎ &")
+}
+
+func TestSkipTaggedFields(t *testing.T) {
+	type Cache struct {
+		Name  string
+		Blob  []byte     `godump:"-"`
+		mu    sync.Mutex `godump:"-"`
+		count int
+	}
+	out := dumpStrT(t, Cache{Name: "c", Blob: []byte("big"), count: 2})
+	assert.NotContains(t, out, "Blob")
+	assert.NotContains(t, out, "mu")
+	assert.Contains(t, out, `+Name  => "c" #string`)
+	assert.Contains(t, out, "-count => 2 #int")
+
+	type Hidden struct {
+		A int    `godump:"-"`
+		b string `godump:"-"`
+	}
+	assert.Equal(t, "#godump.Hidden {}\n", dumpStrT(t, Hidden{A: 1, b: "x"}))
+	assert.Equal(t, "#struct {} {}\n", dumpStrT(t, struct{}{}))
+}
+
+type leakyPIN int
+
+func (p leakyPIN) String() string { return fmt.Sprintf("PIN %d", int(p)) }
+
+func TestRedactTaggedFields(t *testing.T) {
+	type Login struct {
+		User     string
+		Password string   `godump:"redact"`
+		PIN      leakyPIN `godump:"redact"`
+		Retries  int      `json:"retries" godump:"redact"`
+		Card     string   `godump:"redact,keep=4"`
+		Short    string   `godump:"redact,keep=4"`
+	}
+	v := Login{User: "ada", Password: "hunter2", PIN: 1234, Retries: 3, Card: "4111111111111111", Short: "abc"}
+
+	out := dumpStrT(t, v)
+	assert.Contains(t, out, `+User     => "ada" #string`)
+	assert.Contains(t, out, "+Password => <redacted> #string")
+	assert.Contains(t, out, "+PIN      => <redacted> #godump.leakyPIN")
+	assert.Contains(t, out, "+Retries  => <redacted> #int")
+	assert.Contains(t, out, "+Card     => <redacted>…1111 #string")
+	assert.Contains(t, out, "+Short    => <redacted> #string")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "1234")
+	assert.NotContains(t, out, "abc")
+}
+
+func TestWithThemeCustomStringColor(t *testing.T) {
+	const blue = "\033[34m"
+	theme := DefaultTheme()
+	theme.StringColor = blue
+
+	d := NewDumper(WithTheme(theme))
+	d.colorizer = colorizeANSI
+	out := d.DumpStr("hello")
+
+	assert.Contains(t, out, blue+"hello"+colorReset)
+	assert.NotContains(t, out, colorLime)
+	assert.Contains(t, out, colorGray+" #string"+colorReset)
+}
+
+func TestWithThemeMonochrome(t *testing.T) {
+	d := NewDumper(WithTheme(MonochromeTheme()))
+	d.colorizer = colorizeANSI
+	v := map[string]any{"a": 1, "b": nil, "c": []string{"x"}}
+
+	assert.Equal(t, newDumperT(t).DumpStr(v), d.DumpStr(v))
+	assert.NotContains(t, d.DumpStr(v), "\033[")
+}
+
+func TestWithSafeLimitsBoundsPathologicalInput(t *testing.T) {
+	// Deeply nested and wide at the same time.
+	var deep any = "leaf"
+	for i := 0; i < 10000; i++ {
+		deep = []any{deep}
+	}
+	wide := make([]any, 100)
+	for i := range wide {
+		inner := make([]any, 100)
+		for j := range inner {
+			inner[j] = []any{i, j, i * j, i + j}
+		}
+		wide[i] = inner
+	}
+	long := strings.Repeat("x", 1<<20)
+
+	start := time.Now()
+	out := newDumperT(t, WithSafeLimits()).DumpStr(deep, long, wide)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	assert.True(t, len(out) <= safeMaxOutputBytes+100)
+	assert.Contains(t, out, "... (max depth)")
+	assert.Contains(t, out, "... (max nodes)")
+	assert.NotContains(t, out, strings.Repeat("x", safeMaxStringLen+1))
+}
+
+func TestWithSafeLimitsOutputCap(t *testing.T) {
+	d := newDumperT(t, WithSafeLimits())
+	d.maxOutputBytes = 64
+	out := d.DumpStr([]string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta"})
+
+	assert.True(t, strings.HasSuffix(out, "... (output truncated at 64 bytes)\n"))
+	assert.Contains(t, out, "alpha")
+	assert.NotContains(t, out, "zeta")
+}
+
+func TestWithSafeLimitsTimeout(t *testing.T) {
+	d := newDumperT(t, WithSafeLimits())
+	d.timeout = time.Nanosecond
+	out := d.DumpStr([]int{1, 2, 3})
+
+	assert.Contains(t, out, "... (timeout)")
+}
+
+func TestWithSafeLimitsHugeMap(t *testing.T) {
+	huge := make(map[int]int, 200000)
+	for i := 0; i < 200000; i++ {
+		huge[i] = i
+	}
+
+	var totals []int
+	d := newDumperT(t, WithSafeLimits(), WithCallbackOnTruncate(func(_, _ string, total int) {
+		totals = append(totals, total)
+	}))
+	keys := d.mapKeys(reflect.ValueOf(huge), safeMaxItems, &dumpState{})
+	assert.Equal(t, safeMaxNodes, len(keys))
+
+	out := d.DumpStr(huge)
+	assert.Contains(t, out, fmt.Sprintf("... (%d more truncated)", 200000-safeMaxItems))
+	assert.Equal(t, []int{200000}, totals)
+
+	unsorted := newDumperT(t, WithSafeLimits())
+	unsorted.sortMapKeys = false
+	keys = unsorted.mapKeys(reflect.ValueOf(huge), safeMaxItems, &dumpState{})
+	assert.Equal(t, safeMaxItems, len(keys))
+}
+
+func TestWithSafeLimitsDumpSliceAndPath(t *testing.T) {
+	d := newDumperT(t, WithSafeLimits())
+	d.maxNodes = 3
+	out := d.dumpSliceStr(make([]int, 10))
+	assert.Contains(t, out, "[2] 0 #int\n... (7 more truncated)\n")
+	assert.NotContains(t, out, "[3]")
+
+	d = newDumperT(t, WithSafeLimits())
+	d.maxOutputBytes = 32
+	type Wrapper struct{ Items []string }
+	out = d.DumpPath(Wrapper{Items: []string{"alpha", "beta", "gamma", "delta"}}, "/Items")
+	assert.True(t, strings.HasSuffix(out, "... (output truncated at 32 bytes)\n"), out)
+	assert.NotContains(t, out, "delta")
+}
+
+func TestWithSafeLimitsSmallValueUnchanged(t *testing.T) {
+	v := map[string]any{"a": []int{1, 2}, "b": "x"}
+	assert.Equal(t, dumpStrT(t, v), newDumperT(t, WithSafeLimits()).DumpStr(v))
+}
+
+func TestWithCycleFullPath(t *testing.T) {
+	type User struct {
+		Name     string
+		Parent   *User
+		Children []*User
+	}
+	parent := &User{Name: "parent"}
+	child := &User{Name: "child", Parent: parent}
+	parent.Children = []*User{child}
+
+	out := newDumperT(t, WithCycleFullPath()).DumpStr(child)
+	assert.Contains(t, out, "+Parent   => ↩︎ &1 ($.Parent)")
+	assert.NotContains(t, newDumperT(t).DumpStr(child), "($.Parent)")
+
+	type Pair struct {
+		A, B *int
+	}
+	n := 1
+	shared := newDumperT(t, WithCycleFullPath()).DumpStr(&Pair{A: &n, B: &n})
+	assert.Contains(t, shared, "+B => ↩︎ &1 ($.A)")
+}
+
+func TestWithDrainChannels(t *testing.T) {
+	ch := make(chan string, 4)
+	ch <- "a"
+	ch <- "b"
+	ch <- "c"
+
+	out := newDumperT(t, WithDrainChannels()).DumpStr(ch)
+	assert.True(t, strings.HasPrefix(out, "chan string(0x"))
+	assert.True(t, strings.HasSuffix(out, ") [\n  0 => \"a\" #string\n  1 => \"b\" #string\n  2 => \"c\" #string\n] (3 drained)\n"), out)
+	assert.Equal(t, 0, len(ch))
+
+	// Empty channels and dumps without the option leave the channel alone.
+	assert.NotContains(t, newDumperT(t, WithDrainChannels()).DumpStr(ch), "drained")
+	ch <- "d"
+	assert.NotContains(t, dumpStrT(t, ch), "drained")
+	assert.Equal(t, 1, len(ch))
+
+	// Items beyond the max stay in the channel.
+	ch <- "e"
+	ch <- "f"
+	out = newDumperT(t, WithDrainChannels(), WithMaxItems(2)).DumpStr(ch)
+	assert.Contains(t, out, "1 => \"e\" #string\n  ... (1 left in channel)\n] (2 drained)")
+	assert.Equal(t, "f", <-ch)
+
+	type Pipeline struct {
+		in  chan int
+		Out chan<- int
+	}
+	in := make(chan int, 1)
+	in <- 7
+	out = newDumperT(t, WithDrainChannels()).DumpStr(Pipeline{in: in, Out: in})
+	assert.Contains(t, out, "  0 => 7 #int\n  ] (1 drained)")
+	assert.Contains(t, out, "+Out => chan<- int(0x")
+
+	// Unexported channels inside unaddressable map values can still be drained.
+	type pipeline struct {
+		in chan int
+	}
+	mapped := make(chan int, 1)
+	mapped <- 9
+	out = newDumperT(t, WithDrainChannels()).DumpStr(map[string]pipeline{"p": {in: mapped}})
+	assert.Contains(t, out, "0 => 9 #int")
+	assert.Contains(t, out, "(1 drained)")
+	assert.Equal(t, 0, len(mapped))
+}
+
+func TestDumpGroup(t *testing.T) {
+	var buf, extra bytes.Buffer
+	d := newDumperT(t, WithWriter(&buf), WithExtraWriter(&extra))
+	d.DumpGroup("request", func(d *Dumper) {
+		d.Dump("GET")
+		d.DumpGroup("headers", func(d *Dumper) {
+			d.Dump(map[string]string{"Accept": "*/*"})
+		})
+		d.Dump(200)
+	})
+
+	want := `#group "request" {
+  "GET" #string
+  #group "headers" {
+    #map[string]string {
+       Accept => "*/*" #string
+    }
+  }
+  200 #int
+}
+`
+	assert.Equal(t, want, buf.String())
+	assert.Equal(t, want, extra.String())
+}
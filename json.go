@@ -0,0 +1,561 @@
+package godump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxJSONDepth bounds recursion through values that may hold big numbers, mirroring the
+// cycle protection of encoding/json.
+const maxJSONDepth = 1000
+
+var (
+	bigFloatType    = reflect.TypeOf(big.Float{})
+	bigFloatPtrType = reflect.TypeOf((*big.Float)(nil))
+)
+
+// marshalJSON encodes v like json.Marshal, except that *big.Float values are written as
+// exact numeric literals instead of strings. Values holding no big.Float, and subtrees
+// that cannot hold one, are handed to json.Marshal unchanged. A panicking MarshalJSON is
+// returned as an error.
+func marshalJSON(v any) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, err = nil, fmt.Errorf("panic during marshal: %v", r)
+		}
+	}()
+
+	if !containsBigFloat(reflect.ValueOf(v), map[jsonVisit]bool{}) {
+		return json.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, reflect.ValueOf(v), 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// indentJSON pretty-prints compact JSON using the dump indent width.
+func (d *Dumper) indentJSON(b []byte, prefix string) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, prefix, d.indentString(1)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func encodeJSON(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if depth > maxJSONDepth {
+		return errors.New("json: unsupported value: encountered a cycle via " + v.Type().String())
+	}
+
+	t := v.Type()
+	switch {
+	case t == bigFloatType || t == bigFloatPtrType:
+		return encodeBigFloat(buf, v)
+	case !mayContainBigFloat(t, map[reflect.Type]bool{}), hasCustomJSON(t):
+		if !v.CanInterface() {
+			buf.WriteString("null")
+			return nil
+		}
+		target := v.Interface()
+		if v.CanAddr() && v.Kind() != reflect.Ptr {
+			// Keep pointer-receiver marshalers working, as encoding/json does for addressable values.
+			target = v.Addr().Interface()
+		}
+		b, err := json.Marshal(target)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeJSON(buf, v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case reflect.Map:
+		return encodeJSONMap(buf, v, depth)
+	case reflect.Struct:
+		return encodeJSONStruct(buf, v, depth)
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+}
+
+// encodeBigFloat writes a big.Float as its shortest exact decimal form. Infinities have
+// no JSON number form and keep the standard encoding.
+func encodeBigFloat(buf *bytes.Buffer, v reflect.Value) error {
+	var f *big.Float
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		f = v.Interface().(*big.Float)
+	} else {
+		cp := reflect.New(bigFloatType)
+		cp.Elem().Set(v)
+		f = cp.Interface().(*big.Float)
+	}
+
+	if f.IsInf() {
+		b, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+	buf.WriteString(f.Text('g', -1))
+	return nil
+}
+
+func encodeJSONMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+
+	type entry struct {
+		key string
+		val reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := jsonMapKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: key, val: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	buf.WriteByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(buf, e.key)
+		buf.WriteByte(':')
+		if err := encodeJSON(buf, e.val, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// jsonMapKey converts a map key to its JSON object key, following encoding/json.
+func jsonMapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(interface{ MarshalText() ([]byte, error) }); ok {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", errors.New("json: unsupported type: " + k.Type().String())
+}
+
+func encodeJSONStruct(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	buf.WriteByte('{')
+	first := true
+	err := eachJSONField(v, func(name string, fv reflect.Value, quoted bool) error {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeJSONString(buf, name)
+		buf.WriteByte(':')
+		if quoted {
+			return encodeQuotedJSON(buf, fv)
+		}
+		return encodeJSON(buf, fv, depth+1)
+	})
+	if err != nil {
+		return err
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// eachJSONField calls fn for every field encoding/json would emit for struct v, in the
+// same order, honoring tag names, "-", omitempty, and the promotion rules for embedded
+// structs. quoted reports whether the field carries an applicable ",string" option.
+func eachJSONField(v reflect.Value, fn func(name string, fv reflect.Value, quoted bool) error) error {
+	for _, f := range cachedJSONFields(v.Type()) {
+		fv := v
+		for _, i := range f.index {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv = reflect.Value{}
+					break
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(i)
+		}
+		if !fv.IsValid() {
+			continue
+		}
+		if f.omitEmpty && isEmptyJSONValue(fv) {
+			continue
+		}
+		if err := fn(f.name, fv, f.quoted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonField is a struct field as encoding/json sees it, with the index path to reach it
+// through embedded structs.
+type jsonField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+}
+
+// jsonFieldCache maps struct types to their resolved []jsonField.
+var jsonFieldCache sync.Map
+
+func cachedJSONFields(t reflect.Type) []jsonField {
+	if f, ok := jsonFieldCache.Load(t); ok {
+		return f.([]jsonField)
+	}
+	f, _ := jsonFieldCache.LoadOrStore(t, jsonFields(t))
+	return f.([]jsonField)
+}
+
+// jsonFields resolves the fields encoding/json emits for struct type t. Embedded structs
+// are walked breadth first; of several fields sharing a name, the shallowest wins, a
+// tagged field breaks a tie at the same depth, and any other tie drops them all.
+func jsonFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var fields []jsonField
+	var current []embedded
+	next := []embedded{{typ: t}}
+	count, nextCount := map[reflect.Type]int{}, map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if sf.PkgPath != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath != "" {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					f := jsonField{
+						name:      name,
+						index:     index,
+						tagged:    name != "",
+						omitEmpty: hasJSONOption(opts, "omitempty"),
+						quoted:    hasJSONOption(opts, "string") && isQuotableJSON(sf.Type),
+					}
+					if f.name == "" {
+						f.name = sf.Name
+					}
+					fields = append(fields, f)
+					if count[e.typ] > 1 {
+						// The struct was embedded more than once at this depth, so its
+						// fields collide with themselves and must cancel out.
+						fields = append(fields, f)
+					}
+					continue
+				}
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, embedded{typ: ft, index: index})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i], fields[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if len(a.index) != len(b.index) {
+			return len(a.index) < len(b.index)
+		}
+		if a.tagged != b.tagged {
+			return a.tagged
+		}
+		return lessJSONIndex(a.index, b.index)
+	})
+
+	out := fields[:0]
+	for i, advance := 0, 0; i < len(fields); i += advance {
+		for advance = 1; i+advance < len(fields) && fields[i+advance].name == fields[i].name; advance++ {
+		}
+		group := fields[i : i+advance]
+		if len(group) > 1 && len(group[0].index) == len(group[1].index) && group[0].tagged == group[1].tagged {
+			continue
+		}
+		out = append(out, group[0])
+	}
+	sort.Slice(out, func(i, j int) bool { return lessJSONIndex(out[i].index, out[j].index) })
+	return out
+}
+
+// lessJSONIndex orders field index paths by their position in the struct.
+func lessJSONIndex(a, b []int) bool {
+	for k := range a {
+		if k >= len(b) {
+			return false
+		}
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// hasJSONOption reports whether the comma-separated json tag options contain opt.
+func hasJSONOption(opts, opt string) bool {
+	return strings.Contains(","+opts+",", ","+opt+",")
+}
+
+// isQuotableJSON reports whether the ",string" tag option applies to fields of type t,
+// which encoding/json limits to strings, bools, and numbers, or pointers to them.
+func isQuotableJSON(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// encodeQuotedJSON writes a ",string" field the way encoding/json does: its JSON encoding
+// wrapped in a JSON string, with nil pointers left as null.
+func encodeQuotedJSON(buf *bytes.Buffer, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		v = v.Elem()
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	writeJSONString(buf, string(b))
+	return nil
+}
+
+// isEmptyJSONValue matches encoding/json's definition of empty for omitempty.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+	return false
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// hasCustomJSON reports whether t controls its own JSON encoding.
+func hasCustomJSON(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// mayContainBigFloat reports whether values of type t can reach a big.Float, treating
+// interfaces as unknown.
+func mayContainBigFloat(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == bigFloatType || t == bigFloatPtrType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return mayContainBigFloat(t.Elem(), seen)
+	case reflect.Map:
+		return mayContainBigFloat(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if mayContainBigFloat(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonVisit identifies a container on the path walked by containsBigFloat. The type is
+// part of the key because a struct and its first field share an address.
+type jsonVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// containsBigFloat reports whether v actually holds a big.Float that encoding/json would
+// reach, skipping subtrees whose type cannot hold one or that encode themselves. Pointers
+// and containers already on the current path are skipped so cyclic values terminate.
+func containsBigFloat(v reflect.Value, seen map[jsonVisit]bool) bool {
+	if !v.IsValid() {
+		return false
+	}
+	t := v.Type()
+	if t == bigFloatType || t == bigFloatPtrType {
+		return true
+	}
+	if hasCustomJSON(t) || !mayContainBigFloat(t, map[reflect.Type]bool{}) {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return false
+		}
+		key := jsonVisit{ptr: v.Pointer(), typ: t}
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		defer delete(seen, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return containsBigFloat(v.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if containsBigFloat(v.Index(i), seen) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if containsBigFloat(iter.Value(), seen) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		found := false
+		_ = eachJSONField(v, func(_ string, fv reflect.Value, _ bool) error {
+			if !found {
+				found = containsBigFloat(fv, seen)
+			}
+			return nil
+		})
+		return found
+	}
+	return false
+}
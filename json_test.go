@@ -0,0 +1,192 @@
+package godump
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	assert "github.com/goforj/godump/internal/testassert"
+	require "github.com/goforj/godump/internal/testrequire"
+)
+
+type jsonLedger struct {
+	Account string     `json:"account"`
+	Balance *big.Int   `json:"balance"`
+	Rate    *big.Float `json:"rate"`
+	Fee     *big.Float `json:"fee,omitempty"`
+	Note    string     `json:"-"`
+	Entries []any      `json:"entries"`
+}
+
+func TestDumpJSONBigInt(t *testing.T) {
+	n, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	out := NewDumper().DumpJSONStr(map[string]*big.Int{"total": n})
+	assert.Equal(t, "{\n  \"total\": 123456789012345678901234567890\n}", out)
+}
+
+func TestDumpJSONBigFloat(t *testing.T) {
+	rate, _, err := big.ParseFloat("0.1000000000000000000000000001", 10, 200, big.ToNearestEven)
+	require.NoError(t, err)
+
+	d := NewDumper()
+	assert.Equal(t, rate.Text('g', -1), d.DumpJSONStr(rate))
+	assert.True(t, json.Valid([]byte(d.DumpJSONStr(rate))))
+
+	ledger := jsonLedger{
+		Account: "acme",
+		Balance: big.NewInt(42),
+		Rate:    rate,
+		Note:    "hidden",
+		Entries: []any{big.NewFloat(1.5), "x"},
+	}
+	out := d.DumpJSONStr(ledger)
+	assert.Equal(t, "{\n"+
+		"  \"account\": \"acme\",\n"+
+		"  \"balance\": 42,\n"+
+		"  \"rate\": "+rate.Text('g', -1)+",\n"+
+		"  \"entries\": [\n"+
+		"    1.5,\n"+
+		"    \"x\"\n"+
+		"  ]\n"+
+		"}", out)
+
+	var buf bytes.Buffer
+	require.NoError(t, d.DumpJSONStream(&buf, []*big.Float{big.NewFloat(2.25), nil}))
+	assert.Equal(t, "[\n  2.25,\n  null\n]\n", buf.String())
+}
+
+func TestMarshalJSONMatchesStdlib(t *testing.T) {
+	type Embedded struct {
+		Inner string
+	}
+	type Sample struct {
+		Embedded
+		Name  string            `json:"name"`
+		Skip  int               `json:",omitempty"`
+		Map   map[int]any       `json:"map"`
+		Tags  []string          `json:"tags"`
+		Text  map[string]string `json:"text"`
+		Value any
+		lower string
+	}
+	v := Sample{
+		Embedded: Embedded{Inner: "in"},
+		Name:     "<b>",
+		Map:      map[int]any{2: "b", 10: []int{1}},
+		Value:    map[string]any{"z": 1, "a": nil},
+		lower:    "ignored",
+	}
+
+	want, err := json.Marshal(v)
+	require.NoError(t, err)
+	got, err := marshalJSON(v)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestMarshalJSONKeepsTagSemanticsAroundBigFloat(t *testing.T) {
+	type inner struct {
+		A string
+	}
+	type Sample struct {
+		inner
+		B any
+		C int `json:",string"`
+	}
+
+	v := Sample{inner: inner{A: "a"}, B: "plain", C: 7}
+	want, err := json.Marshal(v)
+	require.NoError(t, err)
+	got, err := marshalJSON(v)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	v.B = big.NewFloat(1.25)
+	got, err = marshalJSON(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"A":"a","B":1.25,"C":"7"}`, string(got))
+
+	cyclic := []any{nil, "x"}
+	cyclic[0] = cyclic
+	_, err = marshalJSON(cyclic)
+	require.True(t, err != nil)
+}
+
+type shadowA struct{ X int }
+
+type shadowB struct{ X int }
+
+type shadowTagged struct {
+	X int `json:"X"`
+}
+
+func TestMarshalJSONEmbeddedFieldShadowing(t *testing.T) {
+	type Shadowed struct {
+		shadowA
+		X int
+		F *big.Float
+	}
+	type Tied struct {
+		shadowA
+		shadowB
+		Y int
+		F *big.Float
+	}
+	type TagWins struct {
+		shadowA
+		shadowTagged
+		F *big.Float
+	}
+	type Twice struct {
+		*shadowA
+		Nested struct{ shadowA }
+		F      *big.Float
+	}
+
+	f := big.NewFloat(1.5)
+	for _, v := range []any{
+		Shadowed{shadowA: shadowA{X: 1}, X: 2, F: f},
+		Tied{shadowA: shadowA{X: 1}, shadowB: shadowB{X: 2}, Y: 3, F: f},
+		TagWins{shadowA: shadowA{X: 1}, shadowTagged: shadowTagged{X: 2}, F: f},
+		Twice{F: f},
+	} {
+		want, err := json.Marshal(v)
+		require.NoError(t, err)
+		got, err := marshalJSON(v)
+		require.NoError(t, err)
+		// Only the big.Float differs: a number here, a string from encoding/json.
+		assert.Equal(t, strings.Replace(string(want), `"1.5"`, "1.5", 1), string(got))
+	}
+
+	got, err := marshalJSON(Shadowed{shadowA: shadowA{X: 1}, X: 2, F: f})
+	require.NoError(t, err)
+	assert.Equal(t, `{"X":2,"F":1.5}`, string(got))
+	got, err = marshalJSON(Tied{Y: 3, F: f})
+	require.NoError(t, err)
+	assert.Equal(t, `{"Y":3,"F":1.5}`, string(got))
+}
+
+type panickyJSON struct{}
+
+func (panickyJSON) MarshalJSON() ([]byte, error) {
+	panic("boom")
+}
+
+func TestDumpJSONPanickingMarshaler(t *testing.T) {
+	d := NewDumper()
+
+	out := d.DumpJSONStr(map[string]any{"bad": panickyJSON{}})
+	assert.Equal(t, `{"error":"panic during marshal: boom"}`, out)
+
+	out = d.DumpJSONStr(panickyJSON{})
+	assert.Equal(t, `{"error":"panic during marshal: boom"}`, out)
+
+	var buf bytes.Buffer
+	err := d.DumpJSONStream(&buf, []any{1, panickyJSON{}})
+	require.True(t, err != nil)
+	assert.Equal(t, "panic during marshal: boom", err.Error())
+}
@@ -0,0 +1,141 @@
+package godump
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DumpPath returns a dump of the value found at a slash-separated path inside v.
+// @group Dump
+//
+// Example: dump one nested field
+//
+//	type Profile struct {
+//		Email string
+//	}
+//	type User struct {
+//		Profile Profile
+//	}
+//	out := godump.DumpPath(User{Profile: Profile{Email: "a@b.c"}}, "/Profile/Email")
+//	_ = out
+//	// "a@b.c" #string
+func DumpPath(v any, path string) string {
+	return defaultDumper.DumpPath(v, path)
+}
+
+// DumpPath returns a dump of the value found at a slash-separated path inside v.
+// Segments select struct fields by name, slice and array elements by index, and map
+// entries by key, using JSON Pointer escaping (~1 for "/", ~0 for "~").
+// An invalid path renders an error line instead of a dump.
+// @group Dump
+//
+// Example: dump a slice element with a custom dumper
+//
+//	type User struct {
+//		Tags []string
+//	}
+//	d := godump.NewDumper()
+//	out := d.DumpPath(User{Tags: []string{"admin", "ops"}}, "/Tags/1")
+//	_ = out
+//	// "ops" #string
+func (d *Dumper) DumpPath(v any, path string) string {
+	local := d.clone()
+	target, err := resolvePath(reflect.ValueOf(v), path)
+	if err != nil {
+		return local.colorize(local.theme.ErrorColor, "DumpPath: "+err.Error()) + "\n"
+	}
+
+	state := newDumpState()
+	buf := getDumpBuffer()
+	defer putDumpBuffer(buf)
+	w, done := local.startLimits(buf.tw, state)
+	local.printValue(w, makeAddressable(target), 0, state)
+	fmt.Fprintln(w)
+	done()
+	buf.tw.Flush()
+	return local.wrapToWidth(buf.out.String())
+}
+
+// resolvePath walks v along path and returns the selected value.
+func resolvePath(v reflect.Value, path string) (reflect.Value, error) {
+	if path == "" || path == "/" {
+		return v, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return reflect.Value{}, fmt.Errorf("path %q must start with /", path)
+	}
+
+	v = makeAddressable(v)
+	walked := ""
+	for _, raw := range strings.Split(path[1:], "/") {
+		seg := strings.ReplaceAll(strings.ReplaceAll(raw, "~1", "/"), "~0", "~")
+		walked += "/" + raw
+
+		for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("%s: nil %s", walked, v.Type())
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return reflect.Value{}, fmt.Errorf("%s: invalid value", walked)
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			if _, ok := v.Type().FieldByName(seg); !ok {
+				return reflect.Value{}, fmt.Errorf("%s: no field %q in %s", walked, seg, v.Type())
+			}
+			v = forceExported(v.FieldByName(seg))
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= v.Len() {
+				return reflect.Value{}, fmt.Errorf("%s: index %q out of range for %s of length %d", walked, seg, v.Type(), v.Len())
+			}
+			v = forceExported(v.Index(i))
+		case reflect.Map:
+			key, err := parseMapKey(seg, v.Type().Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s: %w", walked, err)
+			}
+			entry := forceExported(v).MapIndex(key)
+			if !entry.IsValid() {
+				return reflect.Value{}, fmt.Errorf("%s: key %q not found in %s", walked, seg, v.Type())
+			}
+			v = makeAddressable(entry)
+		default:
+			return reflect.Value{}, fmt.Errorf("%s: cannot select %q in %s", walked, seg, v.Type())
+		}
+	}
+	return v, nil
+}
+
+// parseMapKey converts a path segment into a map key of type t.
+func parseMapKey(seg string, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(seg).Convert(t), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(seg, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %q is not a valid %s", seg, t)
+		}
+		return reflect.ValueOf(n).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(seg, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %q is not a valid %s", seg, t)
+		}
+		return reflect.ValueOf(n).Convert(t), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(seg)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %q is not a valid %s", seg, t)
+		}
+		return reflect.ValueOf(b).Convert(t), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported map key type %s", t)
+	}
+}
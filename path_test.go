@@ -0,0 +1,85 @@
+package godump
+
+import (
+	"reflect"
+	"testing"
+
+	assert "github.com/goforj/godump/internal/testassert"
+	require "github.com/goforj/godump/internal/testrequire"
+)
+
+type pathProfile struct {
+	Email string
+	notes []string
+}
+
+type pathUser struct {
+	Name    string
+	Profile *pathProfile
+	Tags    []string
+	Meta    map[string]int
+	ByID    map[int]pathProfile
+}
+
+func newPathUser() pathUser {
+	return pathUser{
+		Name:    "Ada",
+		Profile: &pathProfile{Email: "ada@example.com", notes: []string{"first"}},
+		Tags:    []string{"admin", "ops"},
+		Meta:    map[string]int{"a/b": 1, "logins": 7},
+		ByID:    map[int]pathProfile{42: {Email: "x@example.com"}},
+	}
+}
+
+func TestDumpPathNestedField(t *testing.T) {
+	d := newDumperT(t)
+	assert.Equal(t, "\"ada@example.com\" #string\n", d.DumpPath(newPathUser(), "/Profile/Email"))
+	assert.Equal(t, "\"first\" #string\n", d.DumpPath(newPathUser(), "/Profile/notes/0"))
+
+	out := d.DumpPath(newPathUser(), "/Profile")
+	assert.Contains(t, out, "#*godump.pathProfile {")
+	assert.Contains(t, out, `+Email => "ada@example.com" #string`)
+
+	assert.Contains(t, d.DumpPath(newPathUser(), ""), "#godump.pathUser {")
+}
+
+func TestDumpPathSliceIndex(t *testing.T) {
+	d := newDumperT(t)
+	assert.Equal(t, "\"ops\" #string\n", d.DumpPath(newPathUser(), "/Tags/1"))
+	assert.Equal(t, "\"ops\" #string\n", d.DumpPath(&[]string{"admin", "ops"}, "/1"))
+}
+
+func TestDumpPathMapKey(t *testing.T) {
+	d := newDumperT(t)
+	assert.Equal(t, "7 #int\n", d.DumpPath(newPathUser(), "/Meta/logins"))
+	assert.Equal(t, "1 #int\n", d.DumpPath(newPathUser(), "/Meta/a~1b"))
+	assert.Equal(t, "\"x@example.com\" #string\n", d.DumpPath(newPathUser(), "/ByID/42/Email"))
+}
+
+func TestDumpPathErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"Name", `path "Name" must start with /`},
+		{"/Missing", `/Missing: no field "Missing" in godump.pathUser`},
+		{"/Tags/5", `/Tags/5: index "5" out of range for []string of length 2`},
+		{"/Tags/x", `/Tags/x: index "x" out of range for []string of length 2`},
+		{"/Meta/none", `/Meta/none: key "none" not found in map[string]int`},
+		{"/ByID/abc", `/ByID/abc: key "abc" is not a valid int`},
+		{"/Name/first", `/Name/first: cannot select "first" in string`},
+	}
+	for _, tt := range tests {
+		_, err := resolvePath(reflect.ValueOf(newPathUser()), tt.path)
+		require.True(t, err != nil)
+		assert.Equal(t, tt.want, err.Error(), tt.path)
+	}
+
+	var nilUser pathUser
+	_, err := resolvePath(reflect.ValueOf(nilUser), "/Profile/Email")
+	require.True(t, err != nil)
+	assert.Equal(t, "/Profile/Email: nil *godump.pathProfile", err.Error())
+
+	out := newDumperT(t).DumpPath(newPathUser(), "/Missing")
+	assert.Equal(t, "DumpPath: /Missing: no field \"Missing\" in godump.pathUser\n", out)
+}
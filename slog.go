@@ -0,0 +1,157 @@
+//go:build go1.21
+
+package godump
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewSlogHandler returns a slog.Handler that renders each attribute with a Dumper
+// built from opts and writes records to its writer. Scalars stay on one line and
+// structs, maps, and slices are dumped in full below their key. Records start with
+// the time, level, and message, followed by the dump header for the logging call
+// unless WithoutHeader is set. Groups prefix their attribute keys, e.g. req.id.
+// Records below slog.LevelInfo are dropped unless WithSlogLevel lowers the minimum.
+// @group Dump
+//
+// Example: pretty attributes in structured logs
+//
+//	type User struct {
+//		Name string
+//	}
+//	logger := slog.New(godump.NewSlogHandler(godump.WithoutHeader()))
+//	logger.Info("signed in", "user", User{Name: "Ada"})
+//	// 2026-01-02T15:04:05Z INFO signed in
+//	//   user => #godump.User {
+//	//     +Name => "Ada" #string
+//	//   }
+func NewSlogHandler(opts ...Option) slog.Handler {
+	d := NewDumper(opts...)
+	// Resolve the colorizer up front so concurrent Handle calls only read it.
+	d.ensureColorizer()
+	level, ok := d.slogLevel.(slog.Leveler)
+	if !ok {
+		level = slog.LevelInfo
+	}
+	return &slogHandler{d: d, mu: &sync.Mutex{}, level: level}
+}
+
+// WithSlogLevel sets the minimum level of records written by a handler from
+// NewSlogHandler. Passing a *slog.LevelVar lets the level change at runtime.
+// @group Options
+//
+// Example: include debug records
+//
+//	// Default: slog.LevelInfo
+//	logger := slog.New(godump.NewSlogHandler(godump.WithSlogLevel(slog.LevelDebug)))
+//	logger.Debug("cache miss", "key", "user:1")
+//	// 2026-01-02T15:04:05Z DEBUG cache miss
+//	//   key => "user:1" #string
+func WithSlogLevel(level slog.Leveler) Option {
+	return func(d *Dumper) *Dumper {
+		if level != nil {
+			d.slogLevel = level
+		}
+		return d
+	}
+}
+
+// slogHandler implements slog.Handler on top of a Dumper.
+type slogHandler struct {
+	d      *Dumper
+	mu     *sync.Mutex // shared by handlers derived with WithAttrs and WithGroup
+	prefix string      // open groups, each followed by "."
+	attrs  []slogAttr
+	level  slog.Leveler
+}
+
+// slogAttr is an attribute added with WithAttrs, keyed under the groups open at the time.
+type slogAttr struct {
+	prefix string
+	attr   slog.Attr
+}
+
+// Enabled reports whether level is at or above the handler's minimum level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes the record and its attributes to the dumper's writer.
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	var sb strings.Builder
+	if !r.Time.IsZero() {
+		sb.WriteString(h.d.colorize(h.d.theme.MetaColor, r.Time.Format(time.RFC3339)) + " ")
+	}
+	sb.WriteString(r.Level.String() + " " + r.Message + "\n")
+
+	if !h.d.disableHeader && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		if frame.File != "" {
+			sb.WriteString(h.d.headerLine(frame.File, frame.Line) + "\n")
+		}
+	}
+
+	for _, a := range h.attrs {
+		h.writeAttr(&sb, a.prefix, a.attr)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		h.writeAttr(&sb, h.prefix, a)
+		return true
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.d.writer.Write([]byte(sb.String()))
+	h.d.flush()
+	return err
+}
+
+// writeAttr renders a as an indented "key => value" line, expanding groups into prefixed keys.
+func (h *slogHandler) writeAttr(sb *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			h.writeAttr(sb, prefix, ga)
+		}
+		return
+	}
+
+	value := strings.TrimSuffix(h.d.DumpStr(a.Value.Any()), "\n")
+	value = strings.ReplaceAll(value, "\n", "\n"+h.d.indentString(1))
+	sb.WriteString(h.d.indentString(1) + h.d.colorize(h.d.theme.KeyColor, prefix+a.Key) + " => " + value + "\n")
+}
+
+// WithAttrs returns a handler that adds attrs to every record, under the currently open groups.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = make([]slogAttr, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(h2.attrs, h.attrs)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, slogAttr{prefix: h.prefix, attr: a})
+	}
+	return &h2
+}
+
+// WithGroup returns a handler that prefixes later attribute keys with name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
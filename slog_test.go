@@ -0,0 +1,111 @@
+//go:build go1.21
+
+package godump
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/goforj/godump/internal/testassert"
+)
+
+func TestSlogHandlerDumpsStructAttr(t *testing.T) {
+	type Profile struct {
+		Name string
+		Tags []string
+	}
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(WithWriter(&buf), WithoutColor()))
+	logger.Info("signed in", "user", Profile{Name: "Ada", Tags: []string{"admin"}}, "attempts", 3)
+
+	out := buf.String()
+	assert.True(t, regexp.MustCompile(`^\d{4}-\d\d-\d\dT\S+ INFO signed in\n`).MatchString(out), out)
+	assert.True(t, regexp.MustCompile(`\n<#dump // slog_test\.go:\d+\n`).MatchString(out), out)
+	assert.Contains(t, out, "  user => #godump.Profile {\n")
+	assert.Contains(t, out, "    +Name => \"Ada\" #string\n")
+	assert.Contains(t, out, "      0 => \"admin\" #string\n")
+	assert.Contains(t, out, "  attempts => 3 #int64\n")
+}
+
+func TestSlogHandlerGroupsAndAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(WithWriter(&buf), WithoutColor(), WithoutHeader())).
+		With("service", "api").
+		WithGroup("req").
+		With("id", 7)
+	logger.Warn("slow", slog.Group("timing", slog.Int("ms", 900)), slog.Attr{})
+
+	out := buf.String()
+	assert.NotContains(t, out, "<#dump")
+	assert.Contains(t, out, " WARN slow\n  service => \"api\" #string\n  req.id => 7 #int64\n  req.timing.ms => 900 #int64\n")
+
+	buf.Reset()
+	logger.Info("no attrs")
+	assert.True(t, strings.HasSuffix(buf.String(), " INFO no attrs\n  service => \"api\" #string\n  req.id => 7 #int64\n"), buf.String())
+}
+
+func TestSlogHandlerRecordWithoutTimeOrPC(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewSlogHandler(WithWriter(&buf), WithoutColor())
+	assert.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.False(t, h.Enabled(context.Background(), slog.LevelDebug))
+	assert.Equal(t, h, h.WithGroup(""))
+	assert.Equal(t, h, h.WithAttrs(nil))
+
+	r := slog.NewRecord(time.Time{}, slog.LevelError, "boom", 0)
+	r.AddAttrs(slog.Any("err", nil))
+	assert.NoError(t, h.Handle(context.Background(), r))
+	assert.Equal(t, "ERROR boom\n  err => <invalid>\n", buf.String())
+}
+
+func TestSlogHandlerHeaderFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(WithWriter(&buf), WithoutColor(), WithHeaderFormat(func(file string, line int) string {
+		return "--- " + file + " ---"
+	})))
+	logger.Info("ready")
+	assert.True(t, regexp.MustCompile(` INFO ready\n--- slog_test\.go ---\n$`).MatchString(buf.String()), buf.String())
+}
+
+func TestSlogHandlerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(WithWriter(&buf), WithoutColor(), WithoutHeader()))
+	logger.Debug("hidden")
+	logger.Info("shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), " INFO shown\n")
+
+	var level slog.LevelVar
+	level.Set(slog.LevelWarn)
+	buf.Reset()
+	logger = slog.New(NewSlogHandler(WithWriter(&buf), WithoutColor(), WithoutHeader(), WithSlogLevel(&level)))
+	logger.Info("quiet")
+	logger.Error("loud")
+	assert.NotContains(t, buf.String(), "quiet")
+	assert.Contains(t, buf.String(), " ERROR loud\n")
+
+	level.Set(slog.LevelDebug)
+	logger.Debug("verbose")
+	assert.Contains(t, buf.String(), " DEBUG verbose\n")
+}
+
+func TestSlogHandlerConcurrentRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(WithWriter(&buf), WithoutHeader()))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger.Info("tick", "i", i)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 8, strings.Count(stripANSI(buf.String()), " INFO tick\n  i => "))
+}
@@ -0,0 +1,134 @@
+package godump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DumpYAML dumps the values as YAML.
+// If there is more than one value, they are dumped as a YAML list.
+// It prints an error mapping if marshaling fails.
+// @group YAML
+//
+// Example: print YAML
+//
+//	v := map[string]int{"a": 1}
+//	godump.DumpYAML(v)
+//	// a: 1
+func DumpYAML(vs ...any) {
+	defaultDumper.DumpYAML(vs...)
+}
+
+// DumpYAMLStr dumps the values as a YAML string.
+// @group YAML
+//
+// Example: YAML string
+//
+//	v := map[string]int{"a": 1}
+//	out := godump.DumpYAMLStr(v)
+//	_ = out
+//	// a: 1
+func DumpYAMLStr(vs ...any) string {
+	return defaultDumper.DumpYAMLStr(vs...)
+}
+
+// DumpYAML prints the values as YAML to the configured writer.
+// @group YAML
+//
+// Example: print YAML
+//
+//	v := map[string][]int{"a": {1, 2}}
+//	d := godump.NewDumper()
+//	d.DumpYAML(v)
+//	// a:
+//	//   - 1
+//	//   - 2
+func (d *Dumper) DumpYAML(vs ...any) {
+	fmt.Fprint(d.writer, d.DumpYAMLStr(vs...))
+	d.flush()
+}
+
+// DumpYAMLStr renders values as YAML and returns it as a string. Values are encoded
+// like DumpJSONStr, so json tags and MarshalJSON methods apply, and fields keep their order.
+// @group YAML
+//
+// Example: dump YAML string
+//
+//	type User struct {
+//		Name string   `json:"name"`
+//		Tags []string `json:"tags"`
+//	}
+//	d := godump.NewDumper()
+//	out := d.DumpYAMLStr(User{Name: "Ada", Tags: []string{"admin"}})
+//	_ = out
+//	// name: Ada
+//	// tags:
+//	//   - admin
+func (d *Dumper) DumpYAMLStr(vs ...any) string {
+	if len(vs) == 0 {
+		return d.yamlError(errors.New("DumpYAML called with no arguments"))
+	}
+
+	var data any = vs
+	if len(vs) == 1 {
+		data = vs[0]
+	}
+
+	// Going through JSON keeps json tags, MarshalJSON, and exact big numbers; decoding
+	// into a yaml.Node rather than a map keeps the field order.
+	b, err := marshalJSON(data)
+	if err != nil {
+		return d.yamlError(err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return d.yamlError(err)
+	}
+	clearYAMLStyle(&doc)
+
+	out, err := d.encodeYAML(&doc)
+	if err != nil {
+		return d.yamlError(err)
+	}
+	return out
+}
+
+// encodeYAML renders v as block YAML, indenting nested levels by the dump indent width.
+// yaml.v3 needs at least two spaces to keep nesting unambiguous.
+func (d *Dumper) encodeYAML(v any) (string, error) {
+	indent := d.indentWidth
+	if indent < 2 {
+		indent = 2
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// yamlError renders err as an {"error": ...} mapping, mirroring the DumpJSON fallback.
+func (d *Dumper) yamlError(err error) string {
+	out, encErr := d.encodeYAML(map[string]string{"error": err.Error()})
+	if encErr != nil {
+		return fmt.Sprintf("error: %q\n", err.Error())
+	}
+	return out
+}
+
+// clearYAMLStyle drops the flow and quoting styles n picked up from its JSON source, so
+// the encoder writes block collections and quotes only strings that need it.
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
@@ -0,0 +1,86 @@
+package godump
+
+import (
+	"bytes"
+	"testing"
+
+	assert "github.com/goforj/godump/internal/testassert"
+	require "github.com/goforj/godump/internal/testrequire"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDumpYAML(t *testing.T) {
+	t.Run("no arguments", func(t *testing.T) {
+		assert.Equal(t, "error: DumpYAML called with no arguments\n", DumpYAMLStr())
+	})
+
+	t.Run("single struct", func(t *testing.T) {
+		type Address struct {
+			City string `json:"city"`
+		}
+		type User struct {
+			Name    string   `json:"name"`
+			Age     int      `json:"age"`
+			Tags    []string `json:"tags"`
+			Address Address  `json:"address"`
+			Empty   []int    `json:"empty"`
+		}
+		out := DumpYAMLStr(User{Name: "Alice", Age: 30, Tags: []string{"admin", "ops"}, Address: Address{City: "Paris"}, Empty: []int{}})
+		assert.Equal(t, "name: Alice\nage: 30\ntags:\n  - admin\n  - ops\naddress:\n  city: Paris\nempty: []\n", out)
+	})
+
+	t.Run("multiple values", func(t *testing.T) {
+		assert.Equal(t, "- hello\n- 42\n- true\n", DumpYAMLStr("hello", 42, true))
+	})
+
+	t.Run("list of mappings", func(t *testing.T) {
+		type Item struct {
+			ID   int      `json:"id"`
+			Tags []string `json:"tags"`
+		}
+		out := DumpYAMLStr([]Item{{ID: 1, Tags: []string{"a"}}, {ID: 2}})
+		assert.Equal(t, "- id: 1\n  tags:\n    - a\n- id: 2\n  tags: null\n", out)
+	})
+
+	t.Run("ambiguous strings are quoted", func(t *testing.T) {
+		out := DumpYAMLStr([]string{"", "true", "42", "a: b", "-x", "line\nbreak", "plain text"})
+		assert.Equal(t, "- \"\"\n- \"true\"\n- \"42\"\n- 'a: b'\n- -x\n- |-\n  line\n  break\n- plain text\n", out)
+	})
+
+	t.Run("strings read back as strings", func(t *testing.T) {
+		for _, s := range []string{
+			"0x10", "0o17", "1_000", "+12", "1e3", ".inf", "-.Inf", ".NaN",
+			"2024-01-02", "2024-01-02T15:04:05Z", "~", "null", "NULL", "false", "0x", "v1.2",
+		} {
+			var got any
+			require.NoError(t, yaml.Unmarshal([]byte(DumpYAMLStr(s)), &got))
+			assert.Equal(t, s, got, s)
+		}
+	})
+
+	t.Run("nesting survives a zero indent width", func(t *testing.T) {
+		out := NewDumper(WithIndentWidth(0)).DumpYAMLStr(map[string]any{"a": map[string]any{"b": 1}})
+		assert.Equal(t, "a:\n  b: 1\n", out)
+
+		out = NewDumper(WithIndentWidth(4)).DumpYAMLStr(map[string]any{"a": map[string]any{"b": 1}})
+		assert.Equal(t, "a:\n    b: 1\n", out)
+	})
+
+	t.Run("unmarshallable type", func(t *testing.T) {
+		assert.Equal(t, "error: 'json: unsupported type: chan int'\n", DumpYAMLStr(make(chan int)))
+
+		var got map[string]string
+		require.NoError(t, yaml.Unmarshal([]byte(DumpYAMLStr(make(chan int))), &got))
+		assert.Equal(t, map[string]string{"error": "json: unsupported type: chan int"}, got)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		assert.Equal(t, "null\n", DumpYAMLStr(nil))
+	})
+
+	t.Run("writes to configured writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewDumper(WithWriter(&buf)).DumpYAML(map[string]int{"a": 1})
+		assert.Equal(t, "a: 1\n", buf.String())
+	})
+}